@@ -0,0 +1,348 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts used to parse time.Time values, they cover formats sent by HTML5
+// date and time inputs
+var timeLayouts = []string{
+	"2006-01-02",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"15:04",
+	"15:04:05",
+	"2006-01",
+	time.RFC3339,
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// BindError is returned by Bind when cleaned value can't be assigned to
+// struct field
+type BindError struct {
+	// Name of field in form
+	Field string
+	// Type of destination struct field
+	Type reflect.Type
+	// Cleaned value that couldn't be converted
+	Value interface{}
+	Err   error
+}
+
+func (e *BindError) Error() string {
+	return fmt.Sprintf("forms: can't bind %#v from field %q to %s: %v", e.Value, e.Field, e.Type, e.Err)
+}
+
+// Unwrap returns underlying conversion error
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// parseTag parses struct tag in format "name,option1,key=value", options are
+// returned as map, options without value are mapped to empty string
+func parseTag(tag string) (string, map[string]string) {
+	parts := strings.Split(tag, ",")
+	options := map[string]string{}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) == 2 {
+			options[kv[0]] = kv[1]
+		} else {
+			options[kv[0]] = ""
+		}
+	}
+
+	return strings.TrimSpace(parts[0]), options
+}
+
+// structField describes struct field that is mapped to form field
+type structField struct {
+	name    string
+	options map[string]string
+	field   reflect.StructField
+	index   []int
+}
+
+// structFields returns exported fields of given struct type, in order of
+// declaration, including fields of embedded structs. Field name is taken from
+// "form" tag, or from struct field name when tag is missing. Fields tagged
+// with "-" are skipped.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("form")
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			for _, embedded := range structFields(field.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+		if field.PkgPath != "" || tag == "-" {
+			continue
+		}
+
+		name, options := parseTag(tag)
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, structField{
+			name:    name,
+			options: options,
+			field:   field,
+			index:   []int{i},
+		})
+	}
+
+	return fields
+}
+
+// Bind populates struct pointed by dst with cleaned data. Fields are matched
+// using "form" struct tag, or struct field name when tag is missing, fields
+// tagged with "-" are skipped.
+// Supported are strings, bools, ints, uints, floats, time.Time, slices of them
+// and pointers to them, nil pointer means that no value (or empty string) was
+// given.
+// Example
+//     type Login struct {
+//         Email    string `form:"email"`
+//         Remember *bool  `form:"remember"`
+//     }
+//     var login Login
+//     err := form.Bind(&login)
+func (f *Form) Bind(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("forms: Bind destination must be non-nil pointer to struct")
+	}
+	if f.CleanedData == nil {
+		return errors.New("forms: form has no cleaned data, call IsValid first")
+	}
+
+	v = v.Elem()
+	for _, field := range structFields(v.Type()) {
+		value, ok := f.CleanedData[field.name]
+		if !ok {
+			continue
+		}
+
+		if err := setValue(v.FieldByIndex(field.index), value); err != nil {
+			return &BindError{Field: field.name, Type: field.field.Type, Value: value, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// setValue converts cleaned value and assigns it to v
+func setValue(v reflect.Value, value interface{}) error {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(v.Type()) {
+		v.Set(rv)
+		return nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		// empty inputs are cleaned to empty strings, they mean no value
+		if s, ok := value.(string); ok && s == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	// multi value types clean data to slices, single value can be unwrapped
+	if rv.Kind() == reflect.Slice && v.Kind() != reflect.Slice && rv.Len() <= 1 {
+		if rv.Len() == 0 {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		return setValue(v, rv.Index(0).Interface())
+	}
+
+	if v.Type() == timeType {
+		return setTime(v, value)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		s, ok := anyToString(value)
+		if !ok {
+			return fmt.Errorf("unsupported type %T", value)
+		}
+		v.SetString(s)
+	case reflect.Bool:
+		return setBool(v, value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setInt(v, value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return setUint(v, value)
+	case reflect.Float32, reflect.Float64:
+		return setFloat(v, value)
+	case reflect.Slice:
+		return setSlice(v, rv)
+	default:
+		return fmt.Errorf("unsupported destination type %s", v.Type())
+	}
+
+	return nil
+}
+
+func setTime(v reflect.Value, value interface{}) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("unsupported type %T", value)
+	}
+	if s == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			v.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown time format %q", s)
+}
+
+func setBool(v reflect.Value, value interface{}) error {
+	switch value := value.(type) {
+	case bool:
+		v.SetBool(value)
+	case string:
+		if value == "" {
+			v.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %T", value)
+	}
+
+	return nil
+}
+
+func setInt(v reflect.Value, value interface{}) error {
+	var i int64
+	switch value := value.(type) {
+	case int64:
+		i = value
+	case float64:
+		if value != math.Trunc(value) {
+			return fmt.Errorf("%g is not an integer", value)
+		}
+		i = int64(value)
+	case string:
+		if value == "" {
+			v.SetInt(0)
+			return nil
+		}
+		var err error
+		if i, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported type %T", value)
+	}
+
+	if v.OverflowInt(i) {
+		return fmt.Errorf("%d overflows %s", i, v.Type())
+	}
+	v.SetInt(i)
+
+	return nil
+}
+
+func setUint(v reflect.Value, value interface{}) error {
+	var i int64
+	if err := setInt(reflect.ValueOf(&i).Elem(), value); err != nil {
+		return err
+	}
+	if i < 0 || v.OverflowUint(uint64(i)) {
+		return fmt.Errorf("%d overflows %s", i, v.Type())
+	}
+	v.SetUint(uint64(i))
+
+	return nil
+}
+
+func setFloat(v reflect.Value, value interface{}) error {
+	var f float64
+	switch value := value.(type) {
+	case float64:
+		f = value
+	case int64:
+		f = float64(value)
+	case string:
+		if value == "" {
+			v.SetFloat(0)
+			return nil
+		}
+		var err error
+		if f, err = strconv.ParseFloat(value, 64); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported type %T", value)
+	}
+
+	if v.OverflowFloat(f) {
+		return fmt.Errorf("%g overflows %s", f, v.Type())
+	}
+	v.SetFloat(f)
+
+	return nil
+}
+
+// setSlice assigns every element of given value to new slice, single values
+// are treated as one element slices
+func setSlice(v reflect.Value, rv reflect.Value) error {
+	if rv.Kind() != reflect.Slice {
+		slice := reflect.MakeSlice(v.Type(), 1, 1)
+		if err := setValue(slice.Index(0), rv.Interface()); err != nil {
+			return err
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	slice := reflect.MakeSlice(v.Type(), rv.Len(), rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if err := setValue(slice.Index(i), rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+
+	return nil
+}
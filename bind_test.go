@@ -0,0 +1,172 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bindEmbedded struct {
+	Note string `form:"note"`
+}
+
+type bindTarget struct {
+	bindEmbedded
+	Name     string    `form:"name"`
+	Age      int       `form:"age"`
+	Small    uint8     `form:"small"`
+	Ratio    float64   `form:"ratio"`
+	Accept   bool      `form:"accept"`
+	Born     time.Time `form:"born"`
+	Tags     []string  `form:"tags"`
+	Numbers  []int     `form:"numbers"`
+	Optional *int      `form:"optional"`
+	Missing  *string   `form:"missing"`
+	Untagged string
+	Skipped  string `form:"-"`
+	private  string
+}
+
+func TestFormBind(t *testing.T) {
+	f := Form{CleanedData: Data{
+		"note":     "note",
+		"name":     "John",
+		"age":      int64(30),
+		"small":    int64(8),
+		"ratio":    int64(2),
+		"accept":   true,
+		"born":     "2001-02-03",
+		"tags":     []string{"a", "b"},
+		"numbers":  []string{"1", "2"},
+		"optional": int64(5),
+		"missing":  "",
+		"Untagged": "untagged",
+		"Skipped":  "skipped",
+		"-":        "skipped",
+	}}
+
+	var dst bindTarget
+	assert.NoError(t, f.Bind(&dst))
+
+	five := 5
+	assert.Equal(t, dst, bindTarget{
+		bindEmbedded: bindEmbedded{Note: "note"},
+		Name:         "John",
+		Age:          30,
+		Small:        8,
+		Ratio:        2,
+		Accept:       true,
+		Born:         time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC),
+		Tags:         []string{"a", "b"},
+		Numbers:      []int{1, 2},
+		Optional:     &five,
+		Untagged:     "untagged",
+	})
+}
+
+func TestFormBindTime(t *testing.T) {
+	var dst struct {
+		DateTime time.Time  `form:"datetime"`
+		Time     time.Time  `form:"time"`
+		Empty    *time.Time `form:"empty"`
+	}
+	f := Form{CleanedData: Data{"datetime": "2001-02-03T04:05", "time": "12:30", "empty": ""}}
+
+	assert.NoError(t, f.Bind(&dst))
+	assert.Equal(t, dst.DateTime, time.Date(2001, 2, 3, 4, 5, 0, 0, time.UTC))
+	assert.Equal(t, dst.Time, time.Date(0, 1, 1, 12, 30, 0, 0, time.UTC))
+	assert.Nil(t, dst.Empty)
+}
+
+func TestFormBindAfterIsValid(t *testing.T) {
+	f := New(map[string]*Field{
+		"name":   &Field{},
+		"age":    &Field{Type: &InputNumber{}},
+		"accept": &Field{Type: &Checkbox{}},
+	}, nil)
+	var dst struct {
+		Name   string `form:"name"`
+		Age    *int64 `form:"age"`
+		Accept bool   `form:"accept"`
+	}
+
+	assert.True(t, f.IsValid(url.Values{"name": {"Foo"}, "accept": {"on"}}))
+	assert.NoError(t, f.Bind(&dst))
+	assert.Equal(t, dst.Name, "Foo")
+	assert.Nil(t, dst.Age)
+	assert.True(t, dst.Accept)
+}
+
+func TestFormBindOptional(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "nick"},
+		{Name: "born", Type: &InputDate{}},
+		{Name: "age", Type: &InputNumber{}},
+		{Name: "name"},
+	}, nil)
+	var dst struct {
+		Nick *string    `form:"nick"`
+		Born *time.Time `form:"born"`
+		Age  *int       `form:"age"`
+		Name string     `form:"name"`
+	}
+
+	assert.True(t, f.IsValid(url.Values{}))
+	assert.NoError(t, f.Bind(&dst))
+	assert.Nil(t, dst.Nick, "Empty input should leave pointer nil")
+	assert.Nil(t, dst.Born)
+	assert.Nil(t, dst.Age)
+	assert.Equal(t, dst.Name, "")
+
+	assert.True(t, f.IsValid(url.Values{"nick": {"jo"}, "born": {"2001-02-03"}, "age": {"30"}}))
+	assert.NoError(t, f.Bind(&dst))
+	assert.Equal(t, *dst.Nick, "jo")
+	assert.Equal(t, *dst.Born, time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, *dst.Age, 30)
+}
+
+func TestFormBindErrors(t *testing.T) {
+	var dst struct {
+		Age   int8      `form:"age"`
+		Count uint      `form:"count"`
+		When  time.Time `form:"when"`
+	}
+	var bindErr *BindError
+
+	f := Form{}
+	assert.Error(t, f.Bind(&dst), "Form without cleaned data can't be bound")
+
+	f = Form{CleanedData: Data{}}
+	assert.Error(t, f.Bind(dst), "Destination must be a pointer")
+	assert.Error(t, f.Bind(nil), "Destination must be a pointer")
+	five := 5
+	assert.Error(t, f.Bind(&five), "Destination must be a pointer to struct")
+
+	f = Form{CleanedData: Data{"age": int64(300)}}
+	err := f.Bind(&dst)
+	assert.True(t, errors.As(err, &bindErr))
+	assert.Equal(t, bindErr.Field, "age")
+	assert.Equal(t, bindErr.Value, int64(300))
+
+	f = Form{CleanedData: Data{"age": "abc"}}
+	assert.Error(t, f.Bind(&dst))
+
+	f = Form{CleanedData: Data{"age": 1.5}}
+	assert.Error(t, f.Bind(&dst))
+
+	f = Form{CleanedData: Data{"count": int64(-1)}}
+	assert.Error(t, f.Bind(&dst))
+
+	f = Form{CleanedData: Data{"when": "yesterday"}}
+	assert.Error(t, f.Bind(&dst))
+
+	f = Form{CleanedData: Data{"age": []string{"1", "2"}}}
+	assert.Error(t, f.Bind(&dst))
+
+	f = Form{CleanedData: Data{"age": []string{"1"}}}
+	assert.NoError(t, f.Bind(&dst), "Single value slice should be unwrapped")
+	assert.Equal(t, dst.Age, int8(1))
+}
@@ -0,0 +1,123 @@
+package forms
+
+import (
+	"encoding/json"
+	"html"
+	"log"
+)
+
+// Operators used by conditions
+const (
+	ConditionEquals   = "equals"
+	ConditionIn       = "in"
+	ConditionNotEmpty = "not-empty"
+)
+
+// Condition describes when field is active, depending on value of other field.
+// Inactive fields are not validated and are not put into cleaned data.
+// Example
+//     &forms.Field{
+//         Name:       "company",
+//         Conditions: []forms.Condition{forms.WhenEquals("account_type", "business")},
+//         Validators: []forms.Validator{&forms.Required{}},
+//     }
+type Condition struct {
+	// Field is name of field which value is checked
+	Field    string   `json:"field"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// WhenEquals returns condition met when field has given value
+func WhenEquals(field, value string) Condition {
+	return Condition{Field: field, Operator: ConditionEquals, Values: []string{value}}
+}
+
+// WhenIn returns condition met when field has one of given values
+func WhenIn(field string, values ...string) Condition {
+	return Condition{Field: field, Operator: ConditionIn, Values: values}
+}
+
+// WhenNotEmpty returns condition met when field has non empty value
+func WhenNotEmpty(field string) Condition {
+	return Condition{Field: field, Operator: ConditionNotEmpty}
+}
+
+// IsMet checks if condition is met by given values of field, for multi value
+// fields it's enough that one of the values meets condition
+func (c Condition) IsMet(values []string) bool {
+	for _, value := range values {
+		switch c.Operator {
+		case ConditionEquals, ConditionIn:
+			if valueInSlice(value, c.Values) {
+				return true
+			}
+		case ConditionNotEmpty:
+			if value != "" {
+				return true
+			}
+		default:
+			log.Println(c.Operator, "is incorrect condition operator")
+			return false
+		}
+	}
+
+	return false
+}
+
+// isActive checks if all field's conditions are met, field is inactive as
+// well when field used in condition is inactive. Results are stored in given
+// map, fields with circular conditions are inactive.
+func (f *Form) isActive(field *Field, active map[string]bool) bool {
+	if result, ok := active[field.Name]; ok {
+		return result
+	}
+
+	active[field.Name] = false
+	for _, condition := range field.Conditions {
+		other, ok := f.Fields[condition.Field]
+		if ok && !f.isActive(other, active) {
+			return false
+		}
+
+		var values []string
+		if ok {
+			values = other.Value
+		}
+		if !condition.IsMet(values) {
+			return false
+		}
+	}
+	active[field.Name] = true
+
+	return true
+}
+
+// conditionAttributes returns field's attributes with conditions put into
+// "data-conditions" attribute as JSON, names of fields are the ones used in
+// HTML, so they can be used by client side to toggle field's visibility
+func (f *Field) conditionAttributes() Attributes {
+	attrs := copyAttributes(f.Attributes)
+	if attrs == nil {
+		attrs = Attributes{}
+	}
+
+	conditions := make([]Condition, len(f.Conditions))
+	for i, condition := range f.Conditions {
+		if f.form != nil {
+			if other, ok := f.form.Fields[condition.Field]; ok {
+				condition.Field = other.HTMLName()
+			}
+		}
+		conditions[i] = condition
+	}
+
+	data, err := json.Marshal(conditions)
+	if err != nil {
+		log.Println("Can't encode conditions:", err)
+		return attrs
+	}
+	attrs["data-conditions"] = html.EscapeString(string(data))
+
+	return attrs
+}
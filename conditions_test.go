@@ -0,0 +1,119 @@
+package forms
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newConditionalForm() *Form {
+	return NewOrdered([]*Field{
+		{Name: "account_type", Type: &Radio{}, Choices: []Choice{{Value: "personal"}, {Value: "business"}}},
+		{Name: "company", Conditions: []Condition{WhenEquals("account_type", "business")}, Validators: []Validator{&Required{}}},
+		{Name: "vat_id", Conditions: []Condition{WhenNotEmpty("company")}, Validators: []Validator{&MinLength{10}}},
+		{Name: "nickname", Conditions: []Condition{WhenIn("account_type", "personal", "other")}},
+	}, nil)
+}
+
+func TestConditionIsMet(t *testing.T) {
+	assert.True(t, WhenEquals("a", "b").IsMet([]string{"b"}))
+	assert.False(t, WhenEquals("a", "b").IsMet([]string{"c"}))
+	assert.False(t, WhenEquals("a", "b").IsMet(nil))
+	assert.True(t, WhenIn("a", "b", "c").IsMet([]string{"d", "c"}))
+	assert.False(t, WhenIn("a", "b", "c").IsMet([]string{"d"}))
+	assert.True(t, WhenNotEmpty("a").IsMet([]string{"", "b"}))
+	assert.False(t, WhenNotEmpty("a").IsMet([]string{""}))
+	assert.False(t, Condition{Field: "a", Operator: "unknown"}.IsMet([]string{"b"}))
+}
+
+func TestFormConditionalFields(t *testing.T) {
+	f := newConditionalForm()
+
+	assert.True(t, f.IsValid(url.Values{"account_type": {"personal"}, "vat_id": {"1"}, "nickname": {"john"}}))
+	assert.Equal(t, f.CleanedData, Data{"account_type": "personal", "nickname": "john"})
+
+	assert.False(t, f.IsValid(url.Values{"account_type": {"business"}, "nickname": {"john"}}))
+	assert.Equal(t, errorCodes(f.Fields["company"].Errors), []string{"REQUIRED"})
+	assert.False(t, f.Fields["vat_id"].HasErrors())
+
+	assert.False(t, f.IsValid(url.Values{"account_type": {"business"}, "company": {"ACME"}, "vat_id": {"1"}}))
+	assert.Equal(t, errorCodes(f.Fields["vat_id"].Errors), []string{"INCORRECT_MIN_LENGTH"})
+
+	assert.True(t, f.IsValid(url.Values{"account_type": {"personal"}, "company": {"ACME"}, "vat_id": {"1"}}),
+		"Field should be inactive when field used in its condition is inactive")
+	assert.Equal(t, f.CleanedData, Data{"account_type": "personal", "nickname": ""})
+	assert.Equal(t, f.Fields["company"].Value, []string{"ACME"}, "Inactive field should keep value")
+}
+
+func TestFormConditionalFieldsSchema(t *testing.T) {
+	schema := newConditionalForm().JSONSchema()
+	assert.NotContains(t, schema, "required", "Conditional fields shouldn't be required in schema")
+}
+
+func TestFormCircularConditions(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "a", Conditions: []Condition{WhenNotEmpty("b")}, Validators: []Validator{&Required{}}},
+		{Name: "b", Conditions: []Condition{WhenNotEmpty("a")}, Validators: []Validator{&Required{}}},
+		{Name: "c", Conditions: []Condition{WhenNotEmpty("missing")}, Validators: []Validator{&Required{}}},
+	}, nil)
+
+	assert.True(t, f.IsValid(url.Values{"a": {"1"}, "b": {"2"}}))
+	assert.Equal(t, f.CleanedData, Data{})
+}
+
+func TestFieldRenderConditions(t *testing.T) {
+	f := newConditionalForm()
+	f.Prefix = "p"
+	f.Fields["company"].Attributes = Attributes{"class": "company"}
+
+	assert.Contains(t, f.Fields["company"].Render(),
+		`data-conditions="[{&#34;field&#34;:&#34;p-account_type&#34;,&#34;operator&#34;:&#34;equals&#34;,&#34;values&#34;:[&#34;business&#34;]}]"`)
+	assert.Contains(t, f.Fields["vat_id"].Render(), `&#34;operator&#34;:&#34;not-empty&#34;}]`)
+	assert.NotContains(t, f.Fields["account_type"].Render(), "data-conditions")
+	assert.Equal(t, f.Fields["company"].Attributes, Attributes{"class": "company"})
+}
+
+func TestRequiredIf(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "account_type"},
+		{Name: "company", Validators: []Validator{&RequiredIf{Field: "account_type", Values: []string{"business"}}}},
+	}, nil)
+
+	assert.True(t, f.IsValid(url.Values{"account_type": {"personal"}}))
+	assert.True(t, f.IsValid(url.Values{"account_type": {"business"}, "company": {"ACME"}}))
+	assert.False(t, f.IsValid(url.Values{"account_type": {"business"}}))
+	assert.Equal(t, errorCodes(f.Fields["company"].Errors), []string{"REQUIRED"})
+	assert.Equal(t, f.Fields["company"].Errors[0].Params, Params{"Other": "account_type"})
+
+	assert.True(t, f.Fields["company"].IsValid([]string{}))
+}
+
+func TestRequiredUnless(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "country"},
+		{Name: "state", Validators: []Validator{&RequiredUnless{Field: "country", Values: []string{"PL", "DE"}}}},
+	}, nil)
+
+	assert.True(t, f.IsValid(url.Values{"country": {"PL"}}))
+	assert.True(t, f.IsValid(url.Values{"country": {"US"}, "state": {"CA"}}))
+	assert.False(t, f.IsValid(url.Values{"country": {"US"}}))
+	assert.False(t, f.IsValid(url.Values{}))
+	assert.Equal(t, errorCodes(f.Fields["state"].Errors), []string{"REQUIRED"})
+}
+
+func TestRequiredWith(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "street"},
+		{Name: "city", Conditions: []Condition{WhenEquals("delivery", "yes")}},
+		{Name: "delivery"},
+		{Name: "zip", Validators: []Validator{&RequiredWith{Fields: []string{"street", "city"}}}},
+	}, nil)
+
+	assert.True(t, f.IsValid(url.Values{}))
+	assert.True(t, f.IsValid(url.Values{"city": {"Warsaw"}}), "Values of inactive fields should be ignored")
+	assert.False(t, f.IsValid(url.Values{"city": {"Warsaw"}, "delivery": {"yes"}}))
+	assert.Equal(t, f.Fields["zip"].Errors[0].Params, Params{"Other": "city"})
+	assert.False(t, f.IsValid(url.Values{"street": {"Main"}}))
+	assert.True(t, f.IsValid(url.Values{"street": {"Main"}, "zip": {"00-001"}}))
+}
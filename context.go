@@ -0,0 +1,64 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// ContextValidator is interface for validators that need context, ie. to
+// check in database if username is taken. When field is validated with
+// IsValidContext, context is passed to IsValidContext method, otherwise
+// IsValid is called.
+type ContextValidator interface {
+	Validator
+	IsValidContext(ctx context.Context, values []string) (bool, []*ValidationError)
+}
+
+// ContextValidatorFunc allows to use ordinary function as context validator,
+// IsValid calls it with background context
+// Example
+//     validator := forms.ContextValidatorFunc(func(ctx context.Context, values []string) (bool, []*forms.ValidationError) {
+//         if len(values) > 0 && isTaken(ctx, values[0]) {
+//             return false, []*forms.ValidationError{forms.NewValidationError("TAKEN", values[0], nil)}
+//         }
+//         return true, nil
+//     })
+type ContextValidatorFunc func(ctx context.Context, values []string) (bool, []*ValidationError)
+
+// IsValid calls wrapped function with background context
+func (fn ContextValidatorFunc) IsValid(values []string) (bool, []*ValidationError) {
+	return fn(context.Background(), values)
+}
+
+// IsValidContext calls wrapped function
+func (fn ContextValidatorFunc) IsValidContext(ctx context.Context, values []string) (bool, []*ValidationError) {
+	return fn(ctx, values)
+}
+
+// ContextFormValidator is form validator that needs context, IsValidContext
+// is called instead of IsValid when form is validated with IsValidContext
+type ContextFormValidator interface {
+	FormValidator
+	IsValidContext(ctx context.Context, form *Form, data Data) bool
+}
+
+// IsValidContext works like IsValid, but passes context to validators that
+// implement ContextValidator or ContextFormValidator. When context is
+// cancelled or its deadline is exceeded, validation stops and form gets
+// VALIDATION_CANCELED or VALIDATION_TIMEOUT error.
+func (f *Form) IsValidContext(ctx context.Context, data url.Values) bool {
+	return f.isValid(ctx, submission{values: data})
+}
+
+// contextError adds error describing why context is done and returns false
+func (f *Form) contextError(err error) bool {
+	code := "VALIDATION_CANCELED"
+	if errors.Is(err, context.DeadlineExceeded) {
+		code = "VALIDATION_TIMEOUT"
+	}
+	f.CleanedData = nil
+	f.AddValidationError(NewValidationError(code, "", nil))
+
+	return false
+}
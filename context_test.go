@@ -0,0 +1,105 @@
+package forms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testContextKey is key of values put into contexts in tests
+type testContextKey string
+
+// usernameTaken is context validator that checks if username is taken
+func usernameTaken(taken ...string) ContextValidatorFunc {
+	return func(ctx context.Context, values []string) (bool, []*ValidationError) {
+		if ctx.Value(testContextKey("block")) != nil {
+			<-ctx.Done()
+			return false, []*ValidationError{{Code: "LOOKUP_FAILED", Message: ctx.Err().Error()}}
+		}
+		for _, value := range values {
+			if valueInSlice(value, taken) {
+				return false, []*ValidationError{NewValidationError("TAKEN", value, nil)}
+			}
+		}
+		return true, nil
+	}
+}
+
+func TestFormIsValidContext(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "username", Validators: []Validator{&Required{}, usernameTaken("john")}},
+		{Name: "email"},
+	}, nil)
+
+	assert.True(t, f.IsValidContext(context.Background(), url.Values{"username": {"jane"}}))
+	assert.Equal(t, f.CleanedData["username"], "jane")
+
+	assert.False(t, f.IsValidContext(context.Background(), url.Values{"username": {"john"}}))
+	assert.Equal(t, errorCodes(f.Fields["username"].Errors), []string{"TAKEN"})
+	assert.Equal(t, f.Fields["username"].Errors[0].Field, "username")
+
+	assert.False(t, f.IsValid(url.Values{"username": {"john"}}), "Context validator should be used by IsValid")
+}
+
+func TestFormIsValidContextCancelled(t *testing.T) {
+	called := false
+	f := NewOrdered([]*Field{
+		{Name: "username", Validators: []Validator{usernameTaken()}},
+		{Name: "email", Validators: []Validator{ContextValidatorFunc(func(ctx context.Context, values []string) (bool, []*ValidationError) {
+			called = true
+			return true, nil
+		})}},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, f.IsValidContext(ctx, url.Values{"username": {"jane"}}))
+	assert.Equal(t, errorCodes(f.Errors), []string{"VALIDATION_CANCELED"})
+	assert.False(t, called, "Validation should stop when context is cancelled")
+	assert.Nil(t, f.CleanedData)
+
+	ctx, cancel = context.WithTimeout(context.WithValue(context.Background(), testContextKey("block"), true), 10*time.Millisecond)
+	defer cancel()
+	assert.False(t, f.IsValidContext(ctx, url.Values{"username": {"jane"}}))
+	assert.Equal(t, errorCodes(f.Errors), []string{"VALIDATION_TIMEOUT"})
+	assert.False(t, f.Fields["username"].HasErrors(), "Errors of interrupted validator should be dropped")
+	assert.False(t, called)
+}
+
+type contextFormValidator struct {
+	ctx context.Context
+}
+
+func (v *contextFormValidator) IsValid(form *Form, data Data) bool {
+	return true
+}
+
+func (v *contextFormValidator) IsValidContext(ctx context.Context, form *Form, data Data) bool {
+	v.ctx = ctx
+	return true
+}
+
+func TestFormIsValidContextFormValidator(t *testing.T) {
+	validator := &contextFormValidator{}
+	f := New(map[string]*Field{"name": &Field{}}, nil)
+	f.Validators = []FormValidator{validator}
+
+	ctx := context.WithValue(context.Background(), testContextKey("key"), "value")
+	assert.True(t, f.IsValidContext(ctx, url.Values{}))
+	assert.Equal(t, validator.ctx, ctx)
+}
+
+func TestFormIsValidRequestContext(t *testing.T) {
+	f := New(map[string]*Field{"username": &Field{Validators: []Validator{usernameTaken()}}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := httptest.NewRequest(http.MethodGet, "/?username=jane", nil).WithContext(ctx)
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorCodes(f.Errors), []string{"VALIDATION_CANCELED"})
+}
@@ -0,0 +1,154 @@
+package forms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCSRFFieldName is name of hidden field with CSRF token
+const DefaultCSRFFieldName = "csrf_token"
+
+// DefaultCSRFMaxAge is time for which CSRF token is valid
+const DefaultCSRFMaxAge = 12 * time.Hour
+
+// Errors returned when CSRF token is verified
+var (
+	ErrCSRFInvalid = errors.New("forms: CSRF token is invalid")
+	ErrCSRFExpired = errors.New("forms: CSRF token has expired")
+)
+
+// CSRF protects form against cross-site request forgery. When it's set on
+// form, hidden field with token is rendered in form's opening tag and token is
+// verified when form is validated.
+//
+// Token is signed with HMAC-SHA256 using Secret, it's bound to given session
+// identifier and it's valid for MaxAge.
+// Example
+//     form.CSRF = &forms.CSRF{Secret: secret, SessionID: session.ID}
+type CSRF struct {
+	// Secret key used to sign tokens, it needs to be kept private
+	Secret []byte
+	// Identifier of user's session, token is valid only for this session
+	SessionID string
+	// Time for which token is valid, DefaultCSRFMaxAge is used when zero
+	MaxAge time.Duration
+	// Name of hidden field, DefaultCSRFFieldName is used when empty
+	FieldName string
+
+	// Returns current time, it's replaced in tests
+	now func() time.Time
+}
+
+// fieldName returns name of hidden field
+func (c *CSRF) fieldName() string {
+	if c.FieldName == "" {
+		return DefaultCSRFFieldName
+	}
+
+	return c.FieldName
+}
+
+// maxAge returns time for which token is valid
+func (c *CSRF) maxAge() time.Duration {
+	if c.MaxAge == 0 {
+		return DefaultCSRFMaxAge
+	}
+
+	return c.MaxAge
+}
+
+// currentTime returns current time
+func (c *CSRF) currentTime() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+
+	return c.now()
+}
+
+// sign returns signature of session identifier and timestamp
+func (c *CSRF) sign(timestamp string) []byte {
+	mac := hmac.New(sha256.New, c.Secret)
+	fmt.Fprintf(mac, "%s|%s", c.SessionID, timestamp)
+
+	return mac.Sum(nil)
+}
+
+// Token returns new token for current time, in format "timestamp.signature"
+func (c *CSRF) Token() string {
+	timestamp := strconv.FormatInt(c.currentTime().Unix(), 10)
+
+	return timestamp + "." + base64.RawURLEncoding.EncodeToString(c.sign(timestamp))
+}
+
+// Verify checks if token was signed for current session and if it hasn't
+// expired. Tokens are always invalid when there is no secret.
+func (c *CSRF) Verify(token string) error {
+	parts := strings.SplitN(token, ".", 2)
+	if len(c.Secret) == 0 || len(parts) != 2 {
+		return ErrCSRFInvalid
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, c.sign(parts[0])) {
+		return ErrCSRFInvalid
+	}
+
+	timestamp, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return ErrCSRFInvalid
+	}
+	issued := time.Unix(timestamp, 0)
+	now := c.currentTime()
+	if now.Sub(issued) > c.maxAge() || issued.Sub(now) > time.Minute {
+		return ErrCSRFExpired
+	}
+
+	return nil
+}
+
+// csrfFieldName returns name of form's hidden field with CSRF token
+func (f *Form) csrfFieldName() string {
+	if f.Prefix != "" {
+		return fmt.Sprintf("%s-%s", f.Prefix, f.CSRF.fieldName())
+	}
+
+	return f.CSRF.fieldName()
+}
+
+// RenderCSRF renders hidden field with new CSRF token, it's also rendered by
+// OpenTag, so it's needed only when form tag is rendered by hand
+func (f *Form) RenderCSRF() template.HTML {
+	if f.CSRF == nil {
+		return ""
+	}
+
+	return renderInput(nil, f.csrfFieldName(), "hidden", noUseAttrs, []string{f.CSRF.Token()})
+}
+
+// verifyCSRF checks CSRF token in incoming data and adds form error when
+// it's not valid
+func (f *Form) verifyCSRF(data url.Values) bool {
+	if f.CSRF == nil {
+		return true
+	}
+
+	switch f.CSRF.Verify(data.Get(f.csrfFieldName())) {
+	case nil:
+		return true
+	case ErrCSRFExpired:
+		f.AddValidationError(NewValidationError("CSRF_EXPIRED", "", nil))
+	default:
+		f.AddValidationError(NewValidationError("CSRF_INVALID", "", nil))
+	}
+
+	return false
+}
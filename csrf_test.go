@@ -0,0 +1,82 @@
+package forms
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCSRF(now time.Time) *CSRF {
+	return &CSRF{
+		Secret:    []byte("secret"),
+		SessionID: "session",
+		now:       func() time.Time { return now },
+	}
+}
+
+func TestCSRFToken(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	c := newTestCSRF(now)
+
+	token := c.Token()
+	assert.True(t, strings.HasPrefix(token, "1600000000."))
+	assert.NoError(t, c.Verify(token))
+
+	other := newTestCSRF(now)
+	other.SessionID = "other"
+	assert.Equal(t, other.Verify(token), ErrCSRFInvalid, "Token should be bound to session")
+
+	other = newTestCSRF(now)
+	other.Secret = []byte("other")
+	assert.Equal(t, other.Verify(token), ErrCSRFInvalid, "Token should be bound to secret")
+
+	other = newTestCSRF(now)
+	other.Secret = nil
+	assert.Equal(t, other.Verify(other.Token()), ErrCSRFInvalid, "Token without secret is never valid")
+
+	assert.Equal(t, c.Verify(""), ErrCSRFInvalid)
+	assert.Equal(t, c.Verify("1600000000"), ErrCSRFInvalid)
+	assert.Equal(t, c.Verify("1600000001"+token[10:]), ErrCSRFInvalid, "Timestamp can't be changed")
+	assert.Equal(t, c.Verify("1600000000.!!!"), ErrCSRFInvalid)
+
+	later := newTestCSRF(now.Add(DefaultCSRFMaxAge + time.Second))
+	assert.Equal(t, later.Verify(token), ErrCSRFExpired)
+
+	later.MaxAge = 24 * time.Hour
+	assert.NoError(t, later.Verify(token))
+
+	earlier := newTestCSRF(now.Add(-time.Hour))
+	assert.Equal(t, earlier.Verify(token), ErrCSRFExpired, "Tokens from future aren't accepted")
+}
+
+func TestFormCSRF(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	f := New(map[string]*Field{"field1": &Field{}}, nil)
+	f.CSRF = newTestCSRF(now)
+	token := f.CSRF.Token()
+
+	hidden := `<input name="csrf_token" type="hidden" id="f_csrf_token" value="` + token + `" />`
+	assert.Equal(t, f.RenderCSRF(), template.HTML(hidden))
+	assert.Equal(t, f.OpenTag(), template.HTML("<form>"+hidden))
+
+	assert.False(t, f.IsValid(url.Values{"field1": {"foo"}}))
+	assert.Equal(t, errorCodes(f.Errors), []string{"CSRF_INVALID"})
+	assert.Nil(t, f.CleanedData)
+
+	assert.True(t, f.IsValid(url.Values{"field1": {"foo"}, "csrf_token": {token}}))
+	assert.Equal(t, f.CleanedData, Data{"field1": "foo"}, "Token shouldn't be in cleaned data")
+
+	f.CSRF.now = func() time.Time { return now.Add(DefaultCSRFMaxAge * 2) }
+	assert.False(t, f.IsValid(url.Values{"field1": {"foo"}, "csrf_token": {token}}))
+	assert.Equal(t, errorCodes(f.Errors), []string{"CSRF_EXPIRED"})
+
+	f.CSRF.now = func() time.Time { return now }
+	f.CSRF.FieldName = "token"
+	f.Prefix = "login"
+	assert.Contains(t, f.RenderCSRF(), ` name="login-token" `)
+	assert.True(t, f.IsValid(url.Values{"login-field1": {"foo"}, "login-token": {token}}))
+}
@@ -0,0 +1,94 @@
+package forms
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// FormDefinition is immutable description of form. It's created once, ie. at
+// package level, and used to create separate form for every request, so
+// values, errors and cleaned data are not shared between requests. It's safe
+// for concurrent use.
+// Example
+//     var loginForm = forms.Define(forms.NewOrdered(
+//         []*forms.Field{
+//             &forms.Field{Name: "email", Validators: []forms.Validator{&forms.Required{}}},
+//             &forms.Field{Name: "password", Type: &forms.InputPassword{}},
+//         },
+//         nil,
+//     ))
+//
+//     func login(w http.ResponseWriter, r *http.Request) {
+//         form, ok := loginForm.BindRequest(r, func(form *forms.Form) {
+//             form.CSRF = &forms.CSRF{Secret: secret, SessionID: session(r).ID}
+//         })
+//         // ...
+//     }
+type FormDefinition struct {
+	form *Form
+	// csrf tells if defined form was protected by CSRF
+	csrf bool
+}
+
+// Define creates definition from given form. Form is copied, so its later
+// changes don't affect definition. Validators and types are shared by all
+// forms created from definition, so they need to be safe for concurrent use.
+// CSRF protection is not copied, as it's bound to user's session, it needs to
+// be set by setup function passed to New or Bind methods. If given form has
+// CSRF set, forms bound without CSRF are invalid.
+func Define(form *Form) *FormDefinition {
+	return &FormDefinition{form: form.copy(form.fieldNames()), csrf: form.CSRF != nil}
+}
+
+// New returns new form, that isn't bound to any data, ie. to render empty
+// form. Setup functions are called on the form, ie. to set CSRF protection.
+func (d *FormDefinition) New(setup ...func(*Form)) *Form {
+	form := d.form.copy(d.form.order)
+	for _, fn := range setup {
+		fn(form)
+	}
+
+	return form
+}
+
+// checkCSRF adds error to form and returns false, when definition requires
+// CSRF protection and it wasn't set by setup functions
+func (d *FormDefinition) checkCSRF(form *Form, isValid bool) bool {
+	if d.csrf && form.CSRF == nil {
+		form.AddValidationError(NewValidationError("CSRF_INVALID", "", nil))
+		form.CleanedData = nil
+		return false
+	}
+
+	return isValid
+}
+
+// Bind returns new form validated against given data, result of validation
+// is returned as well. Setup functions are called before validation.
+func (d *FormDefinition) Bind(data url.Values, setup ...func(*Form)) (*Form, bool) {
+	form := d.New(setup...)
+	return form, d.checkCSRF(form, form.IsValid(data))
+}
+
+// BindContext returns new form validated against given data, context is
+// passed to validators like in IsValidContext. Setup functions are called
+// before validation.
+func (d *FormDefinition) BindContext(ctx context.Context, data url.Values, setup ...func(*Form)) (*Form, bool) {
+	form := d.New(setup...)
+	return form, d.checkCSRF(form, form.IsValidContext(ctx, data))
+}
+
+// BindRequest returns new form validated against data from request, like in
+// IsValidRequest. Form uses language negotiated from request's
+// Accept-Language header, unless definition has translator. Setup functions
+// are called before validation, ie. to set CSRF protection bound to request's
+// session.
+func (d *FormDefinition) BindRequest(r *http.Request, setup ...func(*Form)) (*Form, bool) {
+	form := d.New(setup...)
+	if form.Translator == nil {
+		form.Localize(r)
+	}
+
+	return form, d.checkCSRF(form, form.IsValidRequest(r))
+}
@@ -0,0 +1,159 @@
+package forms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDefinition() *FormDefinition {
+	return Define(NewOrdered([]*Field{
+		{Name: "email", Label: "E-mail", Validators: []Validator{&Required{}, &Email{}}},
+		{Name: "age", Type: &InputNumber{}, Attributes: Attributes{"class": "age"}},
+		{Name: "accept", Type: &Checkbox{}},
+		{Name: "food", Type: &Radio{}, Choices: []Choice{{Value: "pizza", Label: "Pizza"}}},
+	}, Attributes{"method": "post"}))
+}
+
+func TestFormDefinition(t *testing.T) {
+	original := New(map[string]*Field{"name": &Field{Validators: []Validator{&Required{}}}}, nil)
+	definition := Define(original)
+	original.AddField(&Field{Name: "other"})
+	original.Fields["name"].Label = "Changed"
+
+	form := definition.New()
+	assert.Equal(t, fieldListNames(form), []string{"name"}, "Changes of original form shouldn't affect definition")
+	assert.Equal(t, form.Fields["name"].Label, "")
+
+	form, ok := definition.Bind(url.Values{"name": {"John"}})
+	assert.True(t, ok)
+	assert.Equal(t, form.CleanedData, Data{"name": "John"})
+
+	other, ok := definition.Bind(url.Values{})
+	assert.False(t, ok)
+	assert.True(t, other.Fields["name"].HasErrors())
+	assert.False(t, form.Fields["name"].HasErrors(), "Bound forms shouldn't share errors")
+	assert.Equal(t, form.CleanedData, Data{"name": "John"})
+	assert.Nil(t, definition.New().Fields["name"].Value)
+}
+
+func TestFormDefinitionBindRequest(t *testing.T) {
+	definition := newDefinition()
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("email=foo"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept-Language", "pl")
+	form, ok := definition.BindRequest(r)
+	assert.False(t, ok)
+	assert.Equal(t, form.Translator, Polish)
+	assert.Nil(t, definition.New().Translator)
+}
+
+func TestFormDefinitionCSRF(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	form := New(map[string]*Field{"name": &Field{}}, nil)
+	form.CSRF = newTestCSRF(now)
+	definition := Define(form)
+	setup := func(form *Form) {
+		form.CSRF = newTestCSRF(now)
+	}
+
+	form, ok := definition.Bind(url.Values{"name": {"John"}}, setup)
+	assert.False(t, ok, "Form without token should be rejected")
+	assert.Equal(t, errorCodes(form.Errors), []string{"CSRF_INVALID"})
+
+	token := newTestCSRF(now).Token()
+	form, ok = definition.Bind(url.Values{"name": {"John"}, DefaultCSRFFieldName: {token}}, setup)
+	assert.True(t, ok)
+	assert.Equal(t, form.CleanedData, Data{"name": "John"})
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=John"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	form, ok = definition.BindRequest(r, setup)
+	assert.False(t, ok)
+	assert.Equal(t, errorCodes(form.Errors), []string{"CSRF_INVALID"})
+
+	form, ok = definition.Bind(url.Values{"name": {"John"}, DefaultCSRFFieldName: {token}})
+	assert.False(t, ok, "Form bound without CSRF should be rejected when definition has one")
+	assert.Equal(t, errorCodes(form.Errors), []string{"CSRF_INVALID"})
+	assert.Nil(t, form.CleanedData)
+
+	form, ok = definition.BindContext(context.Background(), url.Values{"name": {"John"}})
+	assert.False(t, ok)
+
+	assert.Contains(t, definition.New(setup).OpenTag(), DefaultCSRFFieldName)
+}
+
+func TestFormDefinitionConcurrentUse(t *testing.T) {
+	definition := newDefinition()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			email := fmt.Sprintf("user%d@example.com", i)
+			if i%2 == 1 {
+				email = fmt.Sprintf("user%d", i)
+			}
+
+			form, ok := definition.Bind(url.Values{"email": {email}, "age": {fmt.Sprint(i)}, "accept": {"on"}})
+			assert.Equal(t, ok, i%2 == 0)
+			assert.Equal(t, form.Fields["email"].Value, []string{email})
+			assert.Contains(t, form.Render(), fmt.Sprintf(`value="%s"`, email))
+			assert.Contains(t, form.Fields["accept"].Render(), `checked="checked"`)
+			assert.Contains(t, form.OpenTag(), `method="post"`)
+			if ok {
+				assert.Equal(t, form.CleanedData["age"], int64(i))
+				assert.Nil(t, form.Err())
+			} else {
+				assert.Equal(t, errorCodes(form.Fields["email"].Errors), []string{"INCORRECT_EMAIL"})
+				assert.Contains(t, form.Fields["email"].RenderErrors(), email)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	form := definition.New()
+	assert.NotContains(t, form.Fields["accept"].Render(), "checked")
+	assert.Equal(t, form.Fields["age"].Attributes, Attributes{"class": "age"})
+}
+
+func TestFormDefinitionConcurrentSetup(t *testing.T) {
+	valid := FormValidatorFunc(func(form *Form, data Data) bool { return true })
+	form := New(map[string]*Field{"name": &Field{Validators: make([]Validator, 0, 10)}}, nil)
+	form.Validators = append(make([]FormValidator, 0, 10), valid, valid, valid)
+	definition := Define(form)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("user%d", i)
+			form, ok := definition.Bind(url.Values{"name": {name}}, func(form *Form) {
+				form.Validators = append(form.Validators, FormValidatorFunc(func(form *Form, data Data) bool {
+					return data["name"] == name
+				}))
+				field := form.Fields["name"]
+				field.Validators = append(field.Validators, &MinLength{5})
+				field.Choices = append(field.Choices, Choice{Value: name})
+			})
+			assert.True(t, ok, "Validators added by other requests shouldn't be used")
+			assert.Len(t, form.Validators, 4)
+			assert.Len(t, form.Fields["name"].Choices, 1)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, definition.New().Validators, 3)
+	assert.Empty(t, definition.New().Fields["name"].Validators)
+}
@@ -0,0 +1,116 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Params holds parameters of validation error, ie. "Min", "Max" or "Pattern",
+// they are used in error message
+type Params map[string]interface{}
+
+// ValidationError describes single validation error. Its message is created
+// when error is rendered, using message with key equal to error's code, where
+// placeholders like "{Value}" or "{Min}" are replaced with error's value and
+// parameters.
+type ValidationError struct {
+	// Code of error, ie. "REQUIRED" or "INCORRECT_MAX_LENGTH", it's also key of
+	// error's message
+	Code string
+	// Name of field, empty for form errors
+	Field string
+	// Value that failed validation
+	Value string
+	// Parameters of validator
+	Params Params
+	// Message is used instead of message taken from code, it's used by custom
+	// errors added with AddError or AddFieldError
+	Message string
+}
+
+// NewValidationError is shorthand for creating validation error with given
+// code, value and parameters
+func NewValidationError(code, value string, params Params) *ValidationError {
+	return &ValidationError{Code: code, Value: value, Params: params}
+}
+
+// Error returns message of error taken from DefaultTranslator, it's plain
+// text so it needs to be escaped before it's put into HTML
+func (e *ValidationError) Error() string {
+	return e.Translate(DefaultTranslator)
+}
+
+// Translate returns message of error taken from given translator, English
+// message is used when translator doesn't have one
+func (e *ValidationError) Translate(t Translator) string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	if pt, ok := t.(PluralTranslator); ok {
+		if n, ok := pluralCount(e.Params); ok {
+			if msg, ok := pt.TranslatePlural(e.Code, n); ok {
+				return e.format(msg)
+			}
+		}
+	}
+
+	return e.format(translate(t, e.Code))
+}
+
+// format replaces placeholders in message with error's value and parameters
+func (e *ValidationError) format(msg string) string {
+	replacements := []string{"{Value}", e.Value, "{Field}", e.Field}
+	for name, value := range e.Params {
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(value))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(msg)
+}
+
+// ValidationErrors is list of validation errors which implements error, it's
+// returned by form's Err method
+type ValidationErrors []*ValidationError
+
+// Error returns messages of all errors, prefixed with field names
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		if err.Field != "" {
+			msgs[i] = fmt.Sprintf("%s: %s", err.Field, err.Error())
+		} else {
+			msgs[i] = err.Error()
+		}
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// As allows errors.As to get first error from the list
+// Example
+//     var ve *forms.ValidationError
+//     if errors.As(form.Err(), &ve) {
+//         fmt.Println(ve.Code)
+//     }
+func (e ValidationErrors) As(target interface{}) bool {
+	t, ok := target.(**ValidationError)
+	if !ok || len(e) == 0 {
+		return false
+	}
+	*t = e[0]
+
+	return true
+}
+
+// Field returns errors of field with given name, form errors are returned
+// for empty name
+func (e ValidationErrors) Field(name string) ValidationErrors {
+	var errs ValidationErrors
+	for _, err := range e {
+		if err.Field == name {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,81 @@
+package forms
+
+import (
+	"errors"
+	"html/template"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrorError(t *testing.T) {
+	err := NewValidationError("INCORRECT_MIN_LENGTH", "foo", Params{"Min": 4})
+	assert.Equal(t, err.Error(), `Value "foo" need to be at least 4 chars long`)
+
+	err = &ValidationError{Code: "UNKNOWN_{Value}", Value: "foo"}
+	assert.Equal(t, err.Error(), "UNKNOWN_foo", "Code should be used when there is no message")
+
+	err = &ValidationError{Code: "REQUIRED", Message: "Custom message"}
+	assert.Equal(t, err.Error(), "Custom message")
+
+	err = &ValidationError{Code: "FIELDS_NOT_EQUAL", Field: "confirm", Params: Params{"Other": "password"}}
+	assert.Equal(t, err.Error(), `Value doesn't match field "password"`)
+}
+
+func TestValidationErrors(t *testing.T) {
+	errs := ValidationErrors{
+		{Message: "Form error"},
+		{Code: "REQUIRED", Field: "name"},
+		{Code: "INCORRECT_EMAIL", Field: "email", Value: "foo"},
+	}
+
+	assert.Equal(t, errs.Error(), `Form error; name: This field can't be empty; email: "foo" is not correct email address`)
+	assert.Equal(t, errs.Field("email"), ValidationErrors{errs[2]})
+	assert.Equal(t, errs.Field(""), ValidationErrors{errs[0]})
+	assert.Nil(t, errs.Field("missing"))
+
+	var ve *ValidationError
+	assert.True(t, errors.As(errs, &ve))
+	assert.Equal(t, ve, errs[0])
+	assert.False(t, errors.As(ValidationErrors{}, &ve))
+}
+
+func TestFormErr(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "name", Validators: []Validator{&Required{}}},
+		{Name: "email", Validators: []Validator{&Email{}}},
+	}, nil)
+
+	assert.True(t, f.IsValid(url.Values{"name": {"John"}}))
+	assert.Nil(t, f.Err())
+
+	assert.False(t, f.IsValid(url.Values{"email": {"foo"}}))
+	f.AddError("Form error")
+	err := f.Err()
+	assert.Equal(t, err, ValidationErrors{
+		{Message: "Form error"},
+		{Code: "REQUIRED", Field: "name"},
+		{Code: "INCORRECT_EMAIL", Field: "email", Value: "foo"},
+	})
+
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.Equal(t, ve.Message, "Form error")
+}
+
+func TestFormAddValidationError(t *testing.T) {
+	f := New(map[string]*Field{"name": &Field{}}, nil)
+
+	f.AddValidationError(&ValidationError{Code: "TAKEN", Field: "name", Message: "Name <b>is</b> taken"})
+	f.AddValidationError(&ValidationError{Code: "TAKEN", Field: "missing", Message: "Other"})
+	f.AddValidationError(NewValidationError("CSRF_INVALID", "", nil))
+
+	assert.Equal(t, errorCodes(f.Fields["name"].Errors), []string{"TAKEN"})
+	assert.Equal(t, errorMessages(f.Errors), []string{"Other", translations["CSRF_INVALID"]})
+	assert.Equal(
+		t, f.Fields["name"].RenderErrors(),
+		template.HTML("<ul class=\"errors\">\n<li>Name &lt;b&gt;is&lt;/b&gt; taken</li>\n</ul>"),
+		"Messages should be escaped when rendered",
+	)
+}
@@ -1,37 +1,136 @@
 package forms
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"log"
+	"mime/multipart"
+	"net/url"
+	"reflect"
 )
 
 // Choice is used to store choices in field
 // I choose to use type here because we need ordering of choices
+// Choice with nested Choices is a group of choices, ie. rendered as optgroup,
+// it's Value is not used.
 type Choice struct {
-	Value string
-	Label string
+	Value    string
+	Label    string
+	Disabled bool
+	Choices  []Choice
+}
+
+// flattenChoices returns choices with groups replaced by choices they contain
+func flattenChoices(cs []Choice) []Choice {
+	var choices []Choice
+	for _, c := range cs {
+		if len(c.Choices) > 0 {
+			choices = append(choices, flattenChoices(c.Choices)...)
+		} else {
+			choices = append(choices, c)
+		}
+	}
+
+	return choices
 }
 
 // Field represent single field in form and its validatorss
 type Field struct {
 	Name string
+	// Form to which field belongs, it's used to get form's prefix
+	form *Form
+	// bound is set when field's value comes from submitted data, initial
+	// value is rendered only for fields that aren't bound
+	bound bool
 
 	Label           string
 	LabelAttributes Attributes
+	HelpText        string
 
 	Choices      []Choice
 	Value        []string
+	Files        []*multipart.FileHeader
 	InitialValue interface{}
 	Type         Type
 	Attributes   Attributes
 
 	Validators []Validator
-	Errors     []string
+	Errors     []*ValidationError
+	// Conditions that all need to be met for field to be active
+	Conditions []Condition
+	// FreeEntry allows values that aren't among Choices
+	FreeEntry bool
+}
+
+// HTMLName returns name of field used in rendered HTML and in incoming data,
+// it's field's name with form's prefix, if form has one
+func (f *Field) HTMLName() string {
+	if f.form != nil && f.form.Prefix != "" {
+		return fmt.Sprintf("%s-%s", f.form.Prefix, f.Name)
+	}
+
+	return f.Name
+}
+
+// clone returns copy of the field without validation results
+func (f *Field) clone() *Field {
+	field := *f
+	field.Attributes = copyAttributes(f.Attributes)
+	field.LabelAttributes = copyAttributes(f.LabelAttributes)
+	// Slices are capped, so appending to them doesn't change original field
+	field.Validators = f.Validators[:len(f.Validators):len(f.Validators)]
+	field.Choices = f.Choices[:len(f.Choices):len(f.Choices)]
+	field.Conditions = f.Conditions[:len(f.Conditions):len(f.Conditions)]
+	field.Value = nil
+	field.bound = false
+	field.Files = nil
+	field.Errors = nil
+
+	return &field
+}
+
+// IsHidden returns if field is rendered as hidden input
+func (f *Field) IsHidden() bool {
+	_, ok := f.Type.(*InputHidden)
+	return ok
+}
+
+// validateChoices returns errors of values that aren't among field's choices,
+// including nested ones, empty values and fields with FreeEntry are skipped.
+// Disabled choices can't be selected.
+func (f *Field) validateChoices(values []string) []*ValidationError {
+	if len(f.Choices) == 0 || f.FreeEntry {
+		return nil
+	}
+
+	var allowed []string
+	for _, c := range flattenChoices(f.Choices) {
+		if !c.Disabled {
+			allowed = append(allowed, c.Value)
+		}
+	}
+
+	var errs []*ValidationError
+	for _, value := range values {
+		if value != "" && !valueInSlice(value, allowed) {
+			err := NewValidationError("INVALID_CHOICE", value, nil)
+			err.Field = f.Name
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
 }
 
 // IsValid do data validation
-func (f *Field) IsValid(values []string) (isValid bool) {
+func (f *Field) IsValid(values []string) bool {
+	return f.isValid(context.Background(), values, nil)
+}
+
+// isValid validates data, context is passed to context validators. When
+// context is done validation stops and error of validator is dropped.
+func (f *Field) isValid(ctx context.Context, values []string, data url.Values) (isValid bool) {
 	c := len(values)
 
 	if f.Type == nil {
@@ -39,15 +138,45 @@ func (f *Field) IsValid(values []string) (isValid bool) {
 	}
 
 	if !f.Type.IsMultiValue() && c > 1 {
-		f.Errors = append(f.Errors, translations["INCORRECT_MULTI_VAL"])
+		err := NewValidationError("INCORRECT_MULTI_VAL", "", nil)
+		err.Field = f.Name
+		f.Errors = append(f.Errors, err)
+		return false
+	}
+
+	if errs := f.validateChoices(values); len(errs) > 0 {
+		f.Errors = append(f.Errors, errs...)
 		return false
 	}
 
 	isValid = true
 	for _, validator := range f.Validators {
-		result, msgs := validator.IsValid(values)
+		if ctx.Err() != nil {
+			return false
+		}
+
+		var result bool
+		var errs []*ValidationError
+		switch v := validator.(type) {
+		case FileValidator:
+			result, errs = v.IsValidFiles(f.Files)
+		case ContextValidator:
+			result, errs = v.IsValidContext(ctx, values)
+			if ctx.Err() != nil {
+				return false
+			}
+		case DependentValidator:
+			result, errs = v.IsValidData(values, data)
+		default:
+			result, errs = validator.IsValid(values)
+		}
 		if !result {
-			f.Errors = append(f.Errors, msgs...)
+			for _, err := range errs {
+				if err.Field == "" {
+					err.Field = f.Name
+				}
+			}
+			f.Errors = append(f.Errors, errs...)
 			isValid = false
 		}
 	}
@@ -55,31 +184,49 @@ func (f *Field) IsValid(values []string) (isValid bool) {
 	return isValid
 }
 
+// initialValues returns field's initial value as strings, the way it's
+// rendered
+func (f *Field) initialValues() []string {
+	if f.InitialValue == nil {
+		return nil
+	}
+	if !isSlice(f.InitialValue) {
+		if value, ok := anyToString(f.InitialValue); ok {
+			return []string{value}
+		}
+		log.Println(f.InitialValue, "is incorrect type for InitialValue")
+		return nil
+	}
+
+	var values []string
+	slice := reflect.ValueOf(f.InitialValue)
+	for i := 0; i < slice.Len(); i++ {
+		value := slice.Index(i).Interface()
+		if stringValue, ok := anyToString(value); ok {
+			values = append(values, stringValue)
+		} else {
+			log.Println(value, "is incorrect type for InitialValue")
+		}
+	}
+
+	return values
+}
+
 // Render field (in matter of fact, only passing through to render method on type)
 func (f *Field) Render() template.HTML {
 	if f.Type == nil {
 		f.Type = &Input{}
 	}
 
-	var values []string
-	if f.Value == nil && f.InitialValue != nil {
-		if isSlice(f.InitialValue) {
-			for _, value := range f.InitialValue.([]interface{}) {
-				if stringValue, ok := anyToString(value); ok {
-					values = append(values, stringValue)
-				} else {
-					log.Println(value, "is incorrect type for InitialValue")
-				}
-			}
-		} else {
-			if stringValue, ok := anyToString(f.InitialValue); ok {
-				values = append(values, stringValue)
-			} else {
-				log.Println(f.InitialValue, "is incorrect type for InitialValue")
-			}
-		}
-	} else {
-		values = f.Value
+	values := f.Value
+	if !f.bound && f.Value == nil && f.InitialValue != nil {
+		values = f.initialValues()
+	}
+
+	if len(f.Conditions) > 0 {
+		field := *f
+		field.Attributes = f.conditionAttributes()
+		return f.Type.Render(&field, f.Choices, values)
 	}
 
 	return f.Type.Render(f, f.Choices, values)
@@ -89,7 +236,26 @@ func (f *Field) Render() template.HTML {
 func (f *Field) RenderLabel() template.HTML {
 	attributes := prepareAttributes(f.LabelAttributes, []string{"for"})
 
-	return template.HTML(fmt.Sprintf("<label for=\"f_%s\"%s>%s</label>", f.Name, attributes, f.Label))
+	return template.HTML(fmt.Sprintf("<label for=\"f_%s\"%s>%s</label>", f.HTMLName(), attributes, f.label()))
+}
+
+// label returns field's label, translated when there is message with label
+// as key, ie. "DELETE_LABEL"
+func (f *Field) label() string {
+	if f.Label == "" {
+		return ""
+	}
+
+	return translate(f.form.translator(), f.Label)
+}
+
+// RenderHelpText render help text for field, if it's set
+func (f *Field) RenderHelpText() template.HTML {
+	if f.HelpText == "" {
+		return ""
+	}
+
+	return template.HTML(fmt.Sprintf("<span class=\"helptext\">%s</span>", f.HelpText))
 }
 
 // HasErrors returns information if there are validation errors in this field
@@ -99,14 +265,5 @@ func (f *Field) HasErrors() bool {
 
 // RenderErrors render all errors as list (<ul>) with class "errors"
 func (f *Field) RenderErrors() template.HTML {
-	if !f.HasErrors() {
-		return ""
-	}
-
-	rendered := ""
-	for _, err := range f.Errors {
-		rendered += fmt.Sprintf("<li>%s</li>\n", err)
-	}
-
-	return template.HTML(fmt.Sprintf("<ul class=\"errors\">\n%s</ul>", rendered))
+	return renderErrors(f.Errors, f.form.translator())
 }
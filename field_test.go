@@ -2,6 +2,8 @@ package forms
 
 import (
 	"html/template"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,7 +41,33 @@ func TestFieldMultiValue(t *testing.T) {
 	f := Field{}
 	r := f.IsValid([]string{"a", "b"})
 	assert.False(t, r, "Validation should fail when supplying multivalue for non multi value field")
-	assert.Equal(t, f.Errors, []string{translations["INCORRECT_MULTI_VAL"]}, "Field should have defult type Input")
+	assert.Equal(t, errorCodes(f.Errors), []string{"INCORRECT_MULTI_VAL"}, "Field should have defult type Input")
+}
+
+func TestFieldChoicesValidation(t *testing.T) {
+	choices := []Choice{
+		{Value: "sushi", Label: "Sushi"},
+		{Label: "Italian", Choices: []Choice{
+			{Value: "pizza", Label: "Pizza"},
+			{Value: "pasta", Label: "Pasta", Disabled: true},
+		}},
+	}
+
+	f := Field{Name: "food", Type: &SelectMultiple{}, Choices: choices, Validators: []Validator{&MaxSelected{1}}}
+	assert.True(t, f.IsValid([]string{"sushi"}))
+	assert.True(t, f.IsValid([]string{"pizza"}), "Nested choices should be accepted")
+	assert.True(t, f.IsValid([]string{""}), "Empty value should be left for Required")
+
+	assert.False(t, f.IsValid([]string{"sushi", "burger", "pasta"}))
+	assert.Equal(t, errorCodes(f.Errors), []string{"INVALID_CHOICE", "INVALID_CHOICE"})
+	assert.Equal(t, errorMessages(f.Errors), []string{
+		"Value \"burger\" is not one of available choices",
+		"Value \"pasta\" is not one of available choices",
+	})
+	assert.Equal(t, f.Errors[0].Field, "food")
+
+	f = Field{Name: "food", Choices: choices, FreeEntry: true}
+	assert.True(t, f.IsValid([]string{"burger"}), "Field with free entry should accept any value")
 }
 
 func TestFieldRenderLabel(t *testing.T) {
@@ -94,7 +122,7 @@ func TestFieldHandlingErrors(t *testing.T) {
 	assert.False(t, f.HasErrors())
 	assert.Equal(t, f.RenderErrors(), template.HTML(""))
 	f = Field{}
-	f.Errors = []string{"Error"}
+	f.Errors = []*ValidationError{{Message: "Error"}}
 	assert.True(t, f.HasErrors())
 	assert.Equal(t, f.RenderErrors(), template.HTML("<ul class=\"errors\">\n<li>Error</li>\n</ul>"))
 }
@@ -137,6 +165,42 @@ func TestFieldRenderWithInitial(t *testing.T) {
 
 	f = Field{Name: "test1", InitialValue: "123", Value: []string{"incoming"}}
 	assert.Equal(t, f.Render(), template.HTML("<input name=\"test1\" type=\"input\" id=\"f_test1\" value=\"incoming\" />"))
+
+	choices := []Choice{{Value: "a", Label: "A"}, {Label: "Group", Choices: []Choice{{Value: "b", Label: "B"}}}}
+	f = Field{Name: "test1", Type: &SelectMultiple{}, Choices: choices, InitialValue: []interface{}{"a", "b"}}
+	assert.Contains(t, f.Render(), "<option value=\"a\" selected=\"selected\">A</option>")
+	assert.Contains(t, f.Render(), "<option value=\"b\" selected=\"selected\">B</option>")
+
+	f = Field{Name: "test1", Type: &CheckboxMultiple{}, Choices: choices, InitialValue: []string{"b"}}
+	assert.NotContains(t, strings.Split(string(f.Render()), "\n")[0], "checked")
+	assert.Contains(t, strings.Split(string(f.Render()), "\n")[1], ` checked="checked"`)
+
+	f = Field{Name: "test1", Type: &SelectMultiple{}, Choices: choices, InitialValue: []string{"a", "b"}}
+	assert.Contains(t, f.Render(), "<option value=\"a\" selected=\"selected\">A</option>")
+	assert.Contains(t, f.Render(), "<option value=\"b\" selected=\"selected\">B</option>")
+
+	f = Field{Name: "test1", InitialValue: []int{7}}
+	assert.Equal(t, f.Render(), template.HTML("<input name=\"test1\" type=\"input\" id=\"f_test1\" value=\"7\" />"))
+
+	f = Field{Name: "test1", Type: &Select{}, Choices: choices, InitialValue: "a", Value: []string{"b"}}
+	assert.Contains(t, f.Render(), "<option value=\"a\">A</option>")
+	assert.Contains(t, f.Render(), "<option value=\"b\" selected=\"selected\">B</option>")
+}
+
+func TestFieldRenderBoundWithInitial(t *testing.T) {
+	choices := []Choice{{Value: "cheese", Label: "Cheese"}, {Value: "ham", Label: "Ham"}}
+	f := NewOrdered([]*Field{
+		{Name: "toppings", Type: &CheckboxMultiple{}, Choices: choices, InitialValue: []string{"cheese"}},
+		{Name: "sauces", Type: &SelectMultiple{}, Choices: choices, InitialValue: []string{"cheese"}},
+		{Name: "accept", Type: &Checkbox{}, InitialValue: "on"},
+		{Name: "name", Validators: []Validator{&Required{}}},
+	}, nil)
+	assert.Contains(t, f.Fields["toppings"].Render(), "checked")
+
+	assert.False(t, f.IsValid(url.Values{}))
+	assert.NotContains(t, f.Fields["toppings"].Render(), "checked", "Unticked choices shouldn't be checked again")
+	assert.NotContains(t, f.Fields["sauces"].Render(), "selected")
+	assert.NotContains(t, f.Fields["accept"].Render(), "checked")
 }
 
 func TestFieldRenderWithInitialAndErrors(t *testing.T) {
@@ -148,3 +212,11 @@ func TestFieldRenderWithInitialAndErrors(t *testing.T) {
 	f = Field{Name: "test1", InitialValue: []interface{}{Required{}, Input{}}}
 	assert.Equal(t, f.Render(), template.HTML("<input name=\"test1\" type=\"input\" id=\"f_test1\" />"))
 }
+
+func TestFieldRenderHelpText(t *testing.T) {
+	f := Field{Name: "test"}
+	assert.Equal(t, f.RenderHelpText(), template.HTML(""))
+
+	f.HelpText = "Some help"
+	assert.Equal(t, f.RenderHelpText(), template.HTML("<span class=\"helptext\">Some help</span>"))
+}
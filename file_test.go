@@ -0,0 +1,171 @@
+package forms
+
+import (
+	"bytes"
+	"html/template"
+	"mime/multipart"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+
+type testFile struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+// newMultipartForm creates multipart form parsed the same way as it's done by
+// http.Request
+func newMultipartForm(t *testing.T, values url.Values, files ...testFile) *multipart.Form {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for name, vs := range values {
+		for _, v := range vs {
+			assert.NoError(t, w.WriteField(name, v))
+		}
+	}
+	for _, file := range files {
+		part, err := w.CreateFormFile(file.field, file.filename)
+		assert.NoError(t, err)
+		_, err = part.Write(file.content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(body, w.Boundary()).ReadForm(1 << 20)
+	assert.NoError(t, err)
+
+	return form
+}
+
+func TestTypeFile(t *testing.T) {
+	_t := &File{}
+	executeTypeTests(t, _t, TypeTestsSet{
+		name:       "File",
+		multiValue: false,
+		results: TypeTestsResults{
+			{[]string{"a.txt"}, nil},
+			{nil, nil},
+		},
+	})
+
+	f := &Field{Name: "test", Type: _t}
+	assert.Equal(t, _t.Render(f, nil, []string{"a.txt"}), template.HTML(`<input name="test" type="file" id="f_test" />`))
+	assert.Nil(t, f.Attributes, "Rendering shouldn't modify field's attributes")
+
+	header := &multipart.FileHeader{Filename: "a.txt"}
+	assert.Equal(t, _t.CleanFiles([]*multipart.FileHeader{header}), header)
+	assert.Nil(t, _t.CleanFiles(nil))
+}
+
+func TestTypeMultipleFile(t *testing.T) {
+	_t := &MultipleFile{}
+	executeTypeTests(t, _t, TypeTestsSet{
+		name:       "MultipleFile",
+		multiValue: true,
+		results: TypeTestsResults{
+			{[]string{"a.txt"}, []*multipart.FileHeader(nil)},
+		},
+	})
+
+	f := &Field{Name: "test", Type: _t, Attributes: Attributes{"accept": "image/*"}}
+	rendered := _t.Render(f, nil, nil)
+	assert.Contains(t, rendered, `<input name="test" type="file" `)
+	assert.Contains(t, rendered, ` multiple="multiple"`)
+	assert.Contains(t, rendered, ` accept="image/*"`)
+	assert.Equal(t, f.Attributes, Attributes{"accept": "image/*"}, "Rendering shouldn't modify field's attributes")
+
+	headers := []*multipart.FileHeader{{Filename: "a.txt"}, {Filename: "b.txt"}}
+	assert.Equal(t, _t.CleanFiles(headers), headers)
+}
+
+func TestFormIsValidMultipart(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "title", Validators: []Validator{&Required{}}},
+		{Name: "avatar", Type: &File{}, Validators: []Validator{&Required{}, &FileMIME{[]string{"image/*"}}}},
+		{Name: "attachments", Type: &MultipleFile{}},
+	}, nil)
+
+	data := newMultipartForm(t, url.Values{"title": {"Foo"}},
+		testFile{"avatar", "me.png", pngHeader},
+		testFile{"attachments", "a.txt", []byte("a")},
+		testFile{"attachments", "b.txt", []byte("b")},
+	)
+	assert.True(t, f.IsValidMultipart(data))
+	assert.Equal(t, f.CleanedData["title"], "Foo")
+	assert.Equal(t, f.CleanedData["avatar"], data.File["avatar"][0])
+	assert.Equal(t, f.CleanedData["attachments"], data.File["attachments"])
+	assert.Equal(t, f.Fields["avatar"].Value, []string{"me.png"})
+
+	data = newMultipartForm(t, url.Values{"title": {"Foo"}, "avatar": {"fake.png"}})
+	assert.False(t, f.IsValidMultipart(data), "File field can't be filled with ordinary value")
+	assert.Equal(t, errorCodes(f.Fields["avatar"].Errors), []string{"REQUIRED"})
+
+	assert.False(t, f.IsValid(url.Values{"title": {"Foo"}, "avatar": {"fake.png"}}))
+	assert.False(t, f.IsValidMultipart(nil))
+
+	data = newMultipartForm(t, url.Values{"title": {"Foo"}}, testFile{"avatar", "me.png", []byte("text")})
+	assert.False(t, f.IsValidMultipart(data))
+	assert.Equal(t, f.Fields["avatar"].Errors, []*ValidationError{
+		{Code: "FILE_TYPE", Field: "avatar", Value: "me.png", Params: Params{"Type": "text/plain"}},
+	})
+
+	f.Prefix = "p"
+	data = newMultipartForm(t, url.Values{"p-title": {"Foo"}}, testFile{"p-avatar", "me.png", pngHeader})
+	assert.True(t, f.IsValidMultipart(data))
+}
+
+func TestFormOpenTagEnctype(t *testing.T) {
+	f := New(map[string]*Field{"file": &Field{Type: &File{}}}, nil)
+	assert.Equal(t, f.OpenTag(), template.HTML(`<form enctype="multipart/form-data">`))
+	assert.Nil(t, f.Attributes)
+
+	f.Attributes = Attributes{"enctype": "text/plain"}
+	assert.Equal(t, f.OpenTag(), template.HTML(`<form enctype="text/plain">`))
+
+	f = New(map[string]*Field{"text": &Field{}}, nil)
+	assert.Equal(t, f.OpenTag(), template.HTML(`<form>`))
+}
+
+func TestFileValidators(t *testing.T) {
+	data := newMultipartForm(t, nil,
+		testFile{"f", "image.PNG", pngHeader},
+		testFile{"f", "notes.txt", []byte("some notes")},
+	)
+	files := data.File["f"]
+	image, notes := files[0], files[1]
+
+	results := []struct {
+		validator FileValidator
+		files     []*multipart.FileHeader
+		result    bool
+		errors    []*ValidationError
+	}{
+		{&MaxFileSize{100}, files, true, []*ValidationError{}},
+		{&MaxFileSize{10}, files, false, []*ValidationError{NewValidationError("FILE_TOO_BIG", "image.PNG", Params{"Max": int64(10)})}},
+		{&FileExtension{[]string{"png", ".txt"}}, files, true, []*ValidationError{}},
+		{&FileExtension{[]string{".png"}}, files, false, []*ValidationError{NewValidationError("FILE_EXTENSION", "notes.txt", Params{"Extensions": ".png"})}},
+		{&FileExtension{[]string{".png"}}, []*multipart.FileHeader{{Filename: "png"}}, false, []*ValidationError{NewValidationError("FILE_EXTENSION", "png", Params{"Extensions": ".png"})}},
+		{&FileMIME{[]string{"image/png", "text/plain"}}, files, true, []*ValidationError{}},
+		{&FileMIME{[]string{"image/*"}}, []*multipart.FileHeader{image}, true, []*ValidationError{}},
+		{&FileMIME{[]string{"image/*"}}, []*multipart.FileHeader{notes}, false, []*ValidationError{NewValidationError("FILE_TYPE", "notes.txt", Params{"Type": "text/plain"})}},
+		{&FileMIME{[]string{"image/*"}}, []*multipart.FileHeader{{Filename: "missing.png"}}, false, []*ValidationError{NewValidationError("FILE_READ_ERROR", "missing.png", nil)}},
+		{&MaxFiles{2}, files, true, []*ValidationError{}},
+		{&MaxFiles{1}, files, false, []*ValidationError{NewValidationError("TOO_MANY_FILES", "", Params{"Max": 1})}},
+		{&MaxFiles{1}, nil, true, []*ValidationError{}},
+	}
+
+	for _, result := range results {
+		r, errs := result.validator.IsValidFiles(result.files)
+		assert.Equal(t, r, result.result, "Incorrect result for %#v", result.validator)
+		assert.Equal(t, errs, result.errors, "Incorrect errors for %#v", result.validator)
+
+		r, errs = result.validator.IsValid([]string{"anything"})
+		assert.True(t, r)
+		assert.Empty(t, errs)
+	}
+}
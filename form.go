@@ -1,10 +1,14 @@
 package forms
 
 import (
+	"context"
 	"fmt"
 	"html/template"
+	"mime/multipart"
 	"net/url"
 	"reflect"
+	"sort"
+	"sync"
 )
 
 // Attributes is structure that contains forms or fields attributes
@@ -17,11 +21,39 @@ type Data map[string]interface{}
 type Form struct {
 	// Keeps all the fields
 	Fields map[string]*Field
+	// Order in which fields are validated and rendered
+	order []string
+
+	// Prefix is added to names and ids of all fields, it allows to put many
+	// forms with the same fields on one page, ie. "billing" prefix makes
+	// field "email" rendered as "billing-email"
+	Prefix string
 
 	// Form attributes
 	Attributes Attributes
 
-	Errors     []string
+	// Translator of error messages and labels, DefaultTranslator is used when
+	// it's not set
+	Translator Translator
+
+	// Validators that are run on whole form, after all fields are cleaned
+	Validators []FormValidator
+
+	// CSRF protection, when set token is rendered in OpenTag and verified in
+	// IsValid
+	CSRF *CSRF
+
+	// Limit of request body size used by IsValidRequest, DefaultMaxBodySize is
+	// used when zero
+	MaxBodySize int64
+
+	// Number of fields validated at the same time, fields are validated one by
+	// one when it's lower than 2. Validators have to be safe for concurrent
+	// use, results are the same as in sequential validation.
+	Concurrency int
+
+	// Errors of whole form, errors of fields are kept by fields
+	Errors []*ValidationError
 
 	// Data that are used in validation
 	IncomingData url.Values
@@ -34,8 +66,9 @@ type Form struct {
 // Clear clears error and data on fields in form
 func (f *Form) Clear() {
 	f.CleanedData = nil
+	f.Errors = []*ValidationError{}
 	for _, field := range f.Fields {
-		field.Errors = []string{}
+		field.Errors = []*ValidationError{}
 	}
 }
 
@@ -52,20 +85,101 @@ func (f *Form) SetInitial(data Data) {
 // IsValid validate all fields and if all is correct assign cleaned data from
 // every field to forms CleanedData attribute
 func (f *Form) IsValid(data url.Values) bool {
+	return f.isValid(context.Background(), submission{values: data})
+}
+
+// IsValidMultipart validates data from multipart form, uploaded files are
+// passed to fields with file types (File, MultipleFile).
+func (f *Form) IsValidMultipart(data *multipart.Form) bool {
+	if data == nil {
+		return f.isValid(context.Background(), submission{values: url.Values{}})
+	}
+
+	return f.isValid(context.Background(), submission{values: url.Values(data.Value), files: data.File})
+}
+
+// submission holds data sent to form
+type submission struct {
+	values url.Values
+	// Files are used only by fields with file types
+	files map[string][]*multipart.FileHeader
+	// Errors found when data was decoded, keyed by field name, fields with
+	// errors are not validated
+	errors map[string][]*ValidationError
+}
+
+// isValid validates submitted data, names of files are used as values of
+// fields with file types. Validation stops when context is done.
+func (f *Form) isValid(ctx context.Context, s submission) bool {
 	f.Clear()
-	f.IncomingData = data
-	isValid := true
+	defer f.moveHiddenErrors()
+	f.IncomingData = s.values
+	isValid := f.verifyCSRF(s.values)
 	cleanedData := Data{}
 
-	for name, field := range f.Fields {
-		values, _ := data[name]
+	var fields []*Field
+	for _, name := range f.fieldNames() {
+		field := f.Fields[name]
+		var values []string
+		if _, isFile := field.Type.(FileType); isFile {
+			field.Files = s.files[field.HTMLName()]
+			for _, file := range field.Files {
+				values = append(values, file.Filename)
+			}
+		} else {
+			values, _ = s.values[field.HTMLName()]
+		}
 		field.Value = values
+		field.bound = true
+	}
+
+	active := map[string]bool{}
+	data := url.Values{}
+	for _, name := range f.order {
+		field := f.Fields[name]
+		if !f.isActive(field, active) {
+			continue
+		}
+		data[name] = field.Value
+
+		if errors, ok := s.errors[name]; ok {
+			field.Errors = append(field.Errors, errors...)
+			isValid = false
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	results := f.validateFields(ctx, fields, data)
+	if err := ctx.Err(); err != nil {
+		return f.contextError(err)
+	}
 
-		result := field.IsValid(values)
+	for i, field := range fields {
+		if !results[i] {
+			isValid = false
+		} else if fileType, isFile := field.Type.(FileType); isFile {
+			cleanedData[field.Name] = fileType.CleanFiles(field.Files)
+		} else {
+			cleanedData[field.Name] = field.Type.CleanData(field.Value)
+		}
+	}
 
-		if result {
-			cleanedData[name] = field.Type.CleanData(values)
+	if !isValid {
+		return false
+	}
+
+	for _, validator := range f.Validators {
+		var result bool
+		if v, ok := validator.(ContextFormValidator); ok {
+			result = v.IsValidContext(ctx, f, cleanedData)
 		} else {
+			result = validator.IsValid(f, cleanedData)
+		}
+		if err := ctx.Err(); err != nil {
+			return f.contextError(err)
+		}
+		if !result {
 			isValid = false
 		}
 	}
@@ -77,6 +191,181 @@ func (f *Form) IsValid(data url.Values) bool {
 	return isValid
 }
 
+// validateFields validates values of given fields, one by one or using number
+// of goroutines set in Concurrency. Results are in the same order as fields,
+// fields that weren't validated because context was done are invalid. Data
+// holds values of active fields, it's passed to dependent validators.
+func (f *Form) validateFields(ctx context.Context, fields []*Field, data url.Values) []bool {
+	results := make([]bool, len(fields))
+	workers := f.Concurrency
+	if workers > len(fields) {
+		workers = len(fields)
+	}
+	if workers < 2 {
+		for i, field := range fields {
+			if ctx.Err() != nil {
+				break
+			}
+			results[i] = field.isValid(ctx, field.Value, data)
+		}
+		return results
+	}
+
+	jobs := make(chan int)
+	panics := make(chan interface{}, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panics <- r
+					for range jobs {
+					}
+				}
+			}()
+			for i := range jobs {
+				results[i] = fields[i].isValid(ctx, fields[i].Value, data)
+			}
+		}()
+	}
+
+	for i := range fields {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	select {
+	case r := <-panics:
+		panic(r)
+	default:
+	}
+
+	return results
+}
+
+// fieldNames returns names of fields in the order they were declared. Fields
+// that were put directly into Fields map are appended in alphabetical order.
+// It also attaches all fields to form, so they use form's prefix.
+func (f *Form) fieldNames() []string {
+	names := make([]string, 0, len(f.Fields))
+	known := map[string]bool{}
+	for _, name := range f.order {
+		if _, ok := f.Fields[name]; ok && !known[name] {
+			names = append(names, name)
+			known[name] = true
+		}
+	}
+
+	var missing []string
+	for name, field := range f.Fields {
+		if field.Name == "" {
+			field.Name = name
+		}
+		field.form = f
+		if !known[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+
+	f.order = append(names, missing...)
+	return f.order
+}
+
+// FieldList returns fields in the order they were declared
+func (f *Form) FieldList() []*Field {
+	names := f.fieldNames()
+	fields := make([]*Field, len(names))
+	for i, name := range names {
+		fields[i] = f.Fields[name]
+	}
+
+	return fields
+}
+
+// AddField appends field at the end of the form. If there is already field
+// with the same name, it is replaced but keeps its position.
+func (f *Form) AddField(field *Field) {
+	f.InsertField(len(f.Fields), field)
+}
+
+// InsertField inserts field at given position, index is clamped to the
+// boundaries of field list. If there is already field with the same name,
+// it is replaced but keeps its position.
+func (f *Form) InsertField(index int, field *Field) {
+	if f.Fields == nil {
+		f.Fields = map[string]*Field{}
+	}
+
+	names := f.fieldNames()
+	_, exists := f.Fields[field.Name]
+	f.Fields[field.Name] = field
+	field.form = f
+	if exists {
+		return
+	}
+
+	index = clampIndex(index, len(names))
+	f.order = append(names[:index:index], append([]string{field.Name}, names[index:]...)...)
+}
+
+// RemoveField removes field with given name from the form
+func (f *Form) RemoveField(name string) {
+	if _, ok := f.Fields[name]; !ok {
+		return
+	}
+
+	names := f.fieldNames()
+	delete(f.Fields, name)
+	f.order = removeString(names, name)
+}
+
+// MoveField moves field with given name to given position, index is clamped
+// to the boundaries of field list
+func (f *Form) MoveField(name string, index int) {
+	if _, ok := f.Fields[name]; !ok {
+		return
+	}
+
+	names := removeString(f.fieldNames(), name)
+	index = clampIndex(index, len(names))
+	f.order = append(names[:index:index], append([]string{name}, names[index:]...)...)
+}
+
+// clone returns copy of the form, with copied fields, that can be validated
+// independently. Validation results and incoming data are not copied.
+func (f *Form) clone() *Form {
+	return f.copy(f.fieldNames())
+}
+
+// copy returns copy of the form with fields in given order, unlike clone it
+// doesn't modify the form, so it can be called concurrently
+func (f *Form) copy(names []string) *Form {
+	form := &Form{
+		Fields:      make(map[string]*Field, len(f.Fields)),
+		order:       append([]string(nil), names...),
+		Prefix:      f.Prefix,
+		Attributes:  copyAttributes(f.Attributes),
+		Translator:  f.Translator,
+		MaxBodySize: f.MaxBodySize,
+		Concurrency: f.Concurrency,
+		Validators:  f.Validators[:len(f.Validators):len(f.Validators)],
+		InitialData: f.InitialData,
+	}
+	for name, field := range f.Fields {
+		form.Fields[name] = field.clone()
+		form.Fields[name].form = form
+	}
+
+	return form
+}
+
 // IsValidMap populates data from map.
 // It accepts map of string/strings with keys as field names.
 func (f *Form) IsValidMap(values map[string]interface{}) bool {
@@ -97,9 +386,31 @@ func (f *Form) IsValidMap(values map[string]interface{}) bool {
 	return f.IsValid(data)
 }
 
-// OpenTag render opening tag of the form with given attributes
+// OpenTag render opening tag of the form with given attributes, followed by
+// hidden field with CSRF token if form is protected. When form has file field
+// enctype is set to "multipart/form-data".
 func (f *Form) OpenTag() template.HTML {
-	return template.HTML(fmt.Sprintf("<form%s>", prepareAttributes(f.Attributes, nil)))
+	attrs := f.Attributes
+	if _, ok := attrs["enctype"]; !ok && f.hasFileField() {
+		attrs = copyAttributes(attrs)
+		if attrs == nil {
+			attrs = Attributes{}
+		}
+		attrs["enctype"] = "multipart/form-data"
+	}
+
+	return template.HTML(fmt.Sprintf("<form%s>", prepareAttributes(attrs, nil))) + f.RenderCSRF()
+}
+
+// hasFileField checks if there is any field with file type in form
+func (f *Form) hasFileField() bool {
+	for _, field := range f.Fields {
+		if _, ok := field.Type.(FileType); ok {
+			return true
+		}
+	}
+
+	return false
 }
 
 // CloseTag render closing tag for form
@@ -114,21 +425,144 @@ func (f *Form) HasErrors() bool {
 
 // AddError adds new error string to form.
 func (f *Form) AddError(error string) {
-	f.Errors = append(f.Errors, error)
+	f.AddValidationError(&ValidationError{Message: error})
+}
+
+// AddFieldError adds new error string to field with given name, if there is
+// no such field error is added to form.
+func (f *Form) AddFieldError(name, error string) {
+	f.AddValidationError(&ValidationError{Field: name, Message: error})
+}
+
+// AddValidationError adds error to field named in error's Field, if it's empty,
+// there is no such field or field is hidden error is added to form, as form
+// error its Field is cleared.
+// Example
+//     form.AddValidationError(&forms.ValidationError{
+//         Code:    "TAKEN",
+//         Field:   "username",
+//         Value:   "john",
+//         Message: "Username is already taken",
+//     })
+func (f *Form) AddValidationError(err *ValidationError) {
+	field, ok := f.Fields[err.Field]
+	if !ok || field.IsHidden() {
+		err.Field = ""
+		f.Errors = append(f.Errors, err)
+		return
+	}
+
+	field.Errors = append(field.Errors, err)
+}
+
+// moveHiddenErrors moves errors of hidden fields to form's errors, as users
+// can't see hidden fields. Moved errors become form errors, so their Field is
+// cleared.
+func (f *Form) moveHiddenErrors() {
+	for _, field := range f.FieldList() {
+		if field.IsHidden() && len(field.Errors) > 0 {
+			for _, err := range field.Errors {
+				err.Field = ""
+			}
+			f.Errors = append(f.Errors, field.Errors...)
+			field.Errors = nil
+		}
+	}
+}
+
+// Err returns errors of form and its fields as ValidationErrors, or nil when
+// there are no errors. Form errors come first, then errors of fields in
+// their order.
+func (f *Form) Err() error {
+	errs := ValidationErrors{}
+	errs = append(errs, f.Errors...)
+	for _, field := range f.FieldList() {
+		errs = append(errs, field.Errors...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
 }
 
 // RenderErrors render all errors as list (<ul>) with class "errors".
 func (f *Form) RenderErrors() template.HTML {
-	if !f.HasErrors() {
-		return ""
+	return renderErrors(f.Errors, f.translator())
+}
+
+// renderRows renders form errors and every field, except hidden ones, using
+// given row functions
+func (f *Form) renderRows(errorsRow func(template.HTML) string, row func(*Field) string) template.HTML {
+	rendered := ""
+	if f.HasErrors() {
+		rendered += errorsRow(f.RenderErrors())
 	}
 
+	for _, field := range f.FieldList() {
+		if !field.IsHidden() {
+			rendered += row(field)
+		}
+	}
+
+	return template.HTML(rendered)
+}
+
+// RenderHidden renders all hidden fields, they are skipped by Render,
+// AsParagraphs, AsList and AsTable.
+func (f *Form) RenderHidden() template.HTML {
 	rendered := ""
-	for _, err := range f.Errors {
-		rendered += fmt.Sprintf("<li>%s</li>\n", err)
+	for _, field := range f.FieldList() {
+		if field.IsHidden() {
+			rendered += string(field.Render())
+		}
 	}
 
-	return template.HTML(fmt.Sprintf("<ul class=\"errors\">\n%s</ul>", rendered))
+	return template.HTML(rendered)
+}
+
+// AsTable renders all fields as table rows (<tr>), table tag itself is not
+// rendered.
+func (f *Form) AsTable() template.HTML {
+	return f.renderRows(func(errors template.HTML) string {
+		return fmt.Sprintf("<tr><td colspan=\"2\">%s</td></tr>\n", errors)
+	}, func(field *Field) string {
+		return fmt.Sprintf(
+			"<tr><th>%s</th><td>%s%s%s</td></tr>\n",
+			field.RenderLabel(), field.RenderErrors(), field.Render(), field.RenderHelpText(),
+		)
+	})
+}
+
+// AsParagraphs renders all fields wrapped in paragraphs (<p>)
+func (f *Form) AsParagraphs() template.HTML {
+	return f.renderRows(func(errors template.HTML) string {
+		return fmt.Sprintf("%s\n", errors)
+	}, func(field *Field) string {
+		return fmt.Sprintf(
+			"%s<p>%s %s%s</p>\n",
+			field.RenderErrors(), field.RenderLabel(), field.Render(), field.RenderHelpText(),
+		)
+	})
+}
+
+// AsList renders all fields as list items (<li>), list tag itself is not
+// rendered.
+func (f *Form) AsList() template.HTML {
+	return f.renderRows(func(errors template.HTML) string {
+		return fmt.Sprintf("<li>%s</li>\n", errors)
+	}, func(field *Field) string {
+		return fmt.Sprintf(
+			"<li>%s%s %s%s</li>\n",
+			field.RenderErrors(), field.RenderLabel(), field.Render(), field.RenderHelpText(),
+		)
+	})
+}
+
+// Render renders form errors and all fields in declaration order, it's the
+// same as AsParagraphs. Form tags are not rendered.
+func (f *Form) Render() template.HTML {
+	return f.AsParagraphs()
 }
 
 // New is shorthand, and preferred way, to create new form.
@@ -142,13 +576,40 @@ func (f *Form) RenderErrors() template.HTML {
 //         },
 //         forms.Attributes{"id": "test"},
 //     )
+//
+// Because maps are not ordered fields are sorted by their names, use NewOrdered
+// to keep declaration order.
 func New(fields map[string]*Field, attrs Attributes) *Form {
+	form := &Form{
+		Fields:     fields,
+		Attributes: attrs,
+	}
 	for fieldName, field := range fields {
 		field.Name = fieldName
+		field.form = form
 	}
 
-	return &Form{
-		Fields:     fields,
+	return form
+}
+
+// NewOrdered creates new form which keeps fields in the given order, every
+// field needs to have name set.
+// Example
+//     form := forms.NewOrdered(
+//         []*forms.Field{
+//             &forms.Field{Name: "field1"},
+//             &forms.Field{Name: "field2"},
+//         },
+//         forms.Attributes{"id": "test"},
+//     )
+func NewOrdered(fields []*Field, attrs Attributes) *Form {
+	form := &Form{
+		Fields:     map[string]*Field{},
 		Attributes: attrs,
 	}
+	for _, field := range fields {
+		form.AddField(field)
+	}
+
+	return form
 }
@@ -1,9 +1,14 @@
 package forms
 
 import (
+	"context"
+	"fmt"
 	"html/template"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -220,3 +225,313 @@ func TestFormIsValidWithInitial(t *testing.T) {
 	assert.False(t, f.IsValid(url.Values{}))
 	assert.Equal(t, f.CleanedData, Data(nil))
 }
+
+func TestFormValidators(t *testing.T) {
+	called := false
+	f := New(
+		map[string]*Field{
+			"start": &Field{Type: &InputNumber{}, Validators: []Validator{&Required{}}},
+			"end":   &Field{Type: &InputNumber{}},
+		},
+		nil,
+	)
+	f.Validators = []FormValidator{
+		FormValidatorFunc(func(form *Form, data Data) bool {
+			called = true
+			if data["end"].(int64) <= data["start"].(int64) {
+				form.AddError("End must be after start")
+				form.AddFieldError("end", "Too small")
+				return false
+			}
+			return true
+		}),
+	}
+
+	assert.False(t, f.IsValid(url.Values{"end": []string{"2"}}))
+	assert.False(t, called, "Form validators shouldn't run when fields are invalid")
+
+	assert.False(t, f.IsValid(url.Values{"start": []string{"2"}, "end": []string{"1"}}))
+	assert.True(t, called)
+	assert.Equal(t, errorMessages(f.Errors), []string{"End must be after start"})
+	assert.Equal(t, errorMessages(f.Fields["end"].Errors), []string{"Too small"})
+	assert.Equal(t, f.CleanedData, Data(nil))
+
+	assert.True(t, f.IsValid(url.Values{"start": []string{"1"}, "end": []string{"2"}}))
+	assert.Equal(t, f.Errors, []*ValidationError{}, "Errors should be cleared")
+	assert.Equal(t, f.CleanedData, Data{"start": int64(1), "end": int64(2)})
+}
+
+func TestFormAddFieldError(t *testing.T) {
+	f := New(map[string]*Field{"field1": &Field{}}, nil)
+
+	f.AddFieldError("field1", "Error")
+	f.AddFieldError("fieldX", "Other error")
+	assert.Equal(t, f.Fields["field1"].Errors, []*ValidationError{{Field: "field1", Message: "Error"}})
+	assert.Equal(t, f.Errors, []*ValidationError{{Message: "Other error"}}, "Form errors shouldn't have field")
+}
+
+func fieldListNames(f *Form) []string {
+	names := []string{}
+	for _, field := range f.FieldList() {
+		names = append(names, field.Name)
+	}
+
+	return names
+}
+
+func TestFormFieldOrder(t *testing.T) {
+	f := New(
+		map[string]*Field{
+			"c": &Field{},
+			"a": &Field{},
+			"b": &Field{},
+		},
+		nil,
+	)
+	assert.Equal(t, fieldListNames(f), []string{"a", "b", "c"}, "Fields from map should be sorted")
+
+	f = NewOrdered([]*Field{{Name: "c"}, {Name: "a"}, {Name: "b"}}, nil)
+	assert.Equal(t, fieldListNames(f), []string{"c", "a", "b"}, "Declaration order should be kept")
+
+	f.AddField(&Field{Name: "d"})
+	assert.Equal(t, fieldListNames(f), []string{"c", "a", "b", "d"})
+
+	f.InsertField(1, &Field{Name: "e"})
+	assert.Equal(t, fieldListNames(f), []string{"c", "e", "a", "b", "d"})
+
+	f.InsertField(-5, &Field{Name: "f"})
+	assert.Equal(t, fieldListNames(f), []string{"f", "c", "e", "a", "b", "d"})
+
+	replacement := &Field{Name: "a", Label: "A"}
+	f.AddField(replacement)
+	assert.Equal(t, fieldListNames(f), []string{"f", "c", "e", "a", "b", "d"}, "Replaced field should keep position")
+	assert.Equal(t, f.Fields["a"], replacement)
+
+	f.RemoveField("e")
+	f.RemoveField("x")
+	assert.Equal(t, fieldListNames(f), []string{"f", "c", "a", "b", "d"})
+	assert.NotContains(t, f.Fields, "e")
+
+	f.MoveField("d", 0)
+	f.MoveField("f", 100)
+	assert.Equal(t, fieldListNames(f), []string{"d", "c", "a", "b", "f"})
+
+	f.Fields["0"] = &Field{}
+	assert.Equal(t, fieldListNames(f), []string{"d", "c", "a", "b", "f", "0"}, "Fields added to map should be appended")
+}
+
+func TestFormRender(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "b", Label: "B", HelpText: "Help"},
+		{Name: "a", Label: "A"},
+	}, nil)
+
+	assert.Equal(t, f.AsTable(), template.HTML(
+		"<tr><th><label for=\"f_b\">B</label></th><td><input name=\"b\" type=\"input\" id=\"f_b\" /><span class=\"helptext\">Help</span></td></tr>\n"+
+			"<tr><th><label for=\"f_a\">A</label></th><td><input name=\"a\" type=\"input\" id=\"f_a\" /></td></tr>\n",
+	))
+	assert.Equal(t, f.AsList(), template.HTML(
+		"<li><label for=\"f_b\">B</label> <input name=\"b\" type=\"input\" id=\"f_b\" /><span class=\"helptext\">Help</span></li>\n"+
+			"<li><label for=\"f_a\">A</label> <input name=\"a\" type=\"input\" id=\"f_a\" /></li>\n",
+	))
+	assert.Equal(t, f.AsParagraphs(), template.HTML(
+		"<p><label for=\"f_b\">B</label> <input name=\"b\" type=\"input\" id=\"f_b\" /><span class=\"helptext\">Help</span></p>\n"+
+			"<p><label for=\"f_a\">A</label> <input name=\"a\" type=\"input\" id=\"f_a\" /></p>\n",
+	))
+	assert.Equal(t, f.Render(), f.AsParagraphs())
+
+	f.AddError("Form error")
+	f.AddFieldError("a", "Field error")
+	assert.Equal(t, f.AsList(), template.HTML(
+		"<li><ul class=\"errors\">\n<li>Form error</li>\n</ul></li>\n"+
+			"<li><label for=\"f_b\">B</label> <input name=\"b\" type=\"input\" id=\"f_b\" /><span class=\"helptext\">Help</span></li>\n"+
+			"<li><ul class=\"errors\">\n<li>Field error</li>\n</ul><label for=\"f_a\">A</label> <input name=\"a\" type=\"input\" id=\"f_a\" /></li>\n",
+	))
+	assert.Contains(t, f.AsTable(), "<tr><td colspan=\"2\"><ul class=\"errors\">\n<li>Form error</li>\n</ul></td></tr>\n")
+}
+
+func TestFormHiddenFields(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "next", Type: &InputHidden{}, Validators: []Validator{&Required{}}},
+		{Name: "a", Label: "A"},
+		{Name: "ids", Type: &InputHidden{Multiple: true}, InitialValue: []interface{}{"1", "2"}},
+	}, nil)
+
+	assert.Equal(t, f.AsList(), template.HTML("<li><label for=\"f_a\">A</label> <input name=\"a\" type=\"input\" id=\"f_a\" /></li>\n"))
+	assert.Equal(t, f.RenderHidden(), template.HTML(
+		"<input name=\"next\" type=\"hidden\" id=\"f_next\" />"+
+			"<input name=\"ids\" type=\"hidden\" id=\"f_ids_0\" value=\"1\" />\n"+
+			"<input name=\"ids\" type=\"hidden\" id=\"f_ids_1\" value=\"2\" />\n",
+	))
+
+	assert.True(t, f.IsValid(url.Values{"next": {"/home"}, "ids": {"3", "4"}}))
+	assert.Equal(t, f.CleanedData, Data{"next": "/home", "a": "", "ids": []string{"3", "4"}})
+
+	assert.False(t, f.IsValid(url.Values{"a": {"foo"}}))
+	assert.False(t, f.Fields["next"].HasErrors(), "Errors of hidden field should be moved to form")
+	assert.Equal(t, errorCodes(f.Errors), []string{"REQUIRED"})
+	assert.Equal(t, f.Errors[0].Field, "", "Moved errors should be form errors")
+	assert.Contains(t, f.AsList(), "<li><ul class=\"errors\">\n<li>This field can&#39;t be empty</li>\n</ul></li>\n")
+	assert.EqualError(t, f.Err(), "This field can't be empty")
+	assert.Equal(t, errorCodes(f.Err().(ValidationErrors).Field("")), []string{"REQUIRED"})
+	problem := f.Problem(0)
+	assert.Equal(t, problem.Errors, []InvalidParam{{Code: "REQUIRED", Reason: "This field can't be empty"}})
+	assert.Empty(t, problem.InvalidParams)
+
+	f.AddFieldError("ids", "Tampered")
+	assert.Equal(t, errorMessages(f.Errors), []string{"This field can't be empty", "Tampered"})
+	assert.Equal(t, f.Errors[1].Field, "")
+	assert.False(t, f.Fields["ids"].HasErrors())
+}
+
+func TestFormPrefix(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "email", Label: "E-mail", Validators: []Validator{&Required{}}},
+		{Name: "about", Type: &Textarea{}},
+		{Name: "food", Type: &Radio{}, Choices: []Choice{{Value: "pizza", Label: "Pizza"}}},
+	}, nil)
+	f.Prefix = "billing"
+
+	assert.Equal(t, f.Fields["email"].HTMLName(), "billing-email")
+	assert.Equal(t, f.Fields["email"].Render(), template.HTML(`<input name="billing-email" type="input" id="f_billing-email" />`))
+	assert.Equal(t, f.Fields["email"].RenderLabel(), template.HTML(`<label for="f_billing-email">E-mail</label>`))
+	assert.Equal(t, f.Fields["about"].Render(), template.HTML(`<textarea id="f_billing-about" name="billing-about"></textarea>`))
+	assert.Contains(t, f.Fields["food"].Render(), `<label for="c_billing-food_pizza">`)
+	assert.Contains(t, f.Fields["food"].Render(), ` name="billing-food" `)
+
+	assert.False(t, f.IsValid(url.Values{"email": {"foo@example.com"}}), "Unprefixed data should be ignored")
+	assert.True(t, f.IsValid(url.Values{"billing-email": {"foo@example.com"}}))
+	assert.Equal(t, f.CleanedData, Data{"email": "foo@example.com", "about": "", "food": ""})
+
+	other := Form{Fields: map[string]*Field{"email": &Field{}}, Prefix: "shipping"}
+	assert.True(t, other.IsValid(url.Values{"shipping-email": {"bar@example.com"}}))
+	assert.Equal(t, other.CleanedData, Data{"email": "bar@example.com"})
+	assert.Equal(t, other.Fields["email"].HTMLName(), "shipping-email")
+}
+
+func newConcurrentForm(active, maxActive *int32) *Form {
+	slow := ContextValidatorFunc(func(ctx context.Context, values []string) (bool, []*ValidationError) {
+		current := atomic.AddInt32(active, 1)
+		defer atomic.AddInt32(active, -1)
+		for {
+			max := atomic.LoadInt32(maxActive)
+			if current <= max || atomic.CompareAndSwapInt32(maxActive, max, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return true, nil
+	})
+
+	fields := []*Field{}
+	for i := 0; i < 10; i++ {
+		fields = append(fields, &Field{
+			Name:       fmt.Sprintf("field%d", i),
+			Validators: []Validator{&Required{}, &MinLength{Min: 3}, slow},
+		})
+	}
+	fields = append(fields, &Field{Name: "count", Type: &InputNumber{}})
+
+	return NewOrdered(fields, nil)
+}
+
+func TestFormCheckboxMultiple(t *testing.T) {
+	f := New(map[string]*Field{"toppings": &Field{
+		Type:         &CheckboxMultiple{},
+		Choices:      []Choice{{Value: "ham", Label: "Ham"}, {Value: "cheese", Label: "Cheese"}, {Value: "olives", Label: "Olives"}},
+		InitialValue: []interface{}{"cheese"},
+		Validators:   []Validator{&MinSelected{2}, &MaxSelected{2}},
+	}}, nil)
+
+	r := strings.Split(string(f.Fields["toppings"].Render()), "\n")
+	assert.NotContains(t, r[0], "checked")
+	assert.Contains(t, r[1], ` checked="checked"`)
+
+	assert.True(t, f.IsValid(url.Values{"toppings": {"ham", "olives"}}))
+	assert.Equal(t, f.CleanedData, Data{"toppings": []string{"ham", "olives"}})
+	r = strings.Split(string(f.Fields["toppings"].Render()), "\n")
+	assert.Contains(t, r[0], ` checked="checked"`)
+	assert.NotContains(t, r[1], "checked")
+	assert.Contains(t, r[2], ` checked="checked"`)
+
+	assert.False(t, f.IsValid(url.Values{"toppings": {"ham"}}))
+	assert.Equal(t, errorCodes(f.Fields["toppings"].Errors), []string{"TOO_FEW_SELECTED"})
+	assert.False(t, f.IsValid(url.Values{"toppings": {"ham", "cheese", "olives"}}))
+	assert.Equal(t, errorMessages(f.Fields["toppings"].Errors), []string{"Please select at most 2 options"})
+}
+
+func TestFormRadioSelection(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "food", Type: &Radio{}, Choices: []Choice{{Value: "pizza", Label: "Pizza"}, {Value: "pasta", Label: "Pasta"}}, InitialValue: "pasta"},
+		{Name: "email", Validators: []Validator{&Required{}}},
+	}, nil)
+
+	r := strings.Split(string(f.Fields["food"].Render()), "\n")
+	assert.NotContains(t, r[0], "checked")
+	assert.Contains(t, r[1], ` checked="checked"`)
+
+	assert.False(t, f.IsValid(url.Values{"food": {"pizza"}}))
+	r = strings.Split(string(f.Fields["food"].Render()), "\n")
+	assert.Contains(t, r[0], ` checked="checked"`, "Submitted value should be checked after failed validation")
+	assert.NotContains(t, r[1], "checked")
+
+	assert.True(t, f.IsValid(url.Values{"food": {"pizza"}, "email": {"foo@example.com"}}))
+	assert.Equal(t, f.CleanedData["food"], "pizza")
+
+	assert.False(t, f.IsValid(url.Values{"food": {"pizza", "pasta"}, "email": {"foo@example.com"}}))
+	assert.Equal(t, errorCodes(f.Fields["food"].Errors), []string{"INCORRECT_MULTI_VAL"})
+
+	assert.False(t, f.IsValid(url.Values{"food": {"burger"}, "email": {"foo@example.com"}}))
+	assert.Equal(t, errorCodes(f.Fields["food"].Errors), []string{"INVALID_CHOICE"})
+}
+
+func TestFormConcurrentValidation(t *testing.T) {
+	var active, maxActive int32
+	sequential := newConcurrentForm(&active, &maxActive)
+	concurrent := newConcurrentForm(&active, &maxActive)
+	concurrent.Concurrency = 3
+
+	data := url.Values{"field1": {"a"}, "field2": {"foo"}, "field3": {"ab", "cd"}, "count": {"12"}}
+	for i := 4; i < 10; i++ {
+		data.Set(fmt.Sprintf("field%d", i), "value")
+	}
+
+	assert.Equal(t, concurrent.IsValid(data), sequential.IsValid(data))
+	assert.Equal(t, concurrent.Err(), sequential.Err())
+	assert.Equal(t, errorCodes(concurrent.Fields["field1"].Errors), []string{"INCORRECT_MIN_LENGTH"})
+	assert.LessOrEqual(t, maxActive, int32(3), "Concurrency should be bounded")
+	assert.Greater(t, maxActive, int32(1), "Fields should be validated concurrently")
+
+	data.Set("field0", "value")
+	data.Set("field1", "value")
+	data.Set("field3", "value")
+	assert.True(t, concurrent.IsValid(data))
+	assert.True(t, sequential.IsValid(data))
+	assert.Equal(t, concurrent.CleanedData, sequential.CleanedData)
+	assert.Equal(t, concurrent.CleanedData["count"], int64(12))
+}
+
+func TestFormConcurrentValidationCancelled(t *testing.T) {
+	var active, maxActive int32
+	f := newConcurrentForm(&active, &maxActive)
+	f.Concurrency = 4
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, f.IsValidContext(ctx, url.Values{"field0": {"value"}}))
+	assert.Equal(t, errorCodes(f.Errors), []string{"VALIDATION_CANCELED"})
+	assert.Nil(t, f.CleanedData)
+}
+
+func TestFormConcurrentValidationPanic(t *testing.T) {
+	f := New(map[string]*Field{
+		"a": &Field{},
+		"b": &Field{Validators: []Validator{ContextValidatorFunc(func(ctx context.Context, values []string) (bool, []*ValidationError) {
+			panic("validator failed")
+		})}},
+		"c": &Field{},
+	}, nil)
+	f.Concurrency = 2
+
+	assert.PanicsWithValue(t, "validator failed", func() { f.IsValid(url.Values{}) })
+}
@@ -0,0 +1,352 @@
+package forms
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Names of fields in formset's management form, they are prefixed with
+// formset's prefix, ie. "form-TOTAL_FORMS"
+const (
+	TotalFormsName   = "TOTAL_FORMS"
+	InitialFormsName = "INITIAL_FORMS"
+	MinNumFormsName  = "MIN_NUM_FORMS"
+	MaxNumFormsName  = "MAX_NUM_FORMS"
+
+	// Name of field that marks form to deletion
+	DeletionFieldName = "DELETE"
+	// Name of field that holds form's position
+	OrderingFieldName = "ORDER"
+)
+
+// DefaultFormSetPrefix is prefix used when formset has no prefix set
+const DefaultFormSetPrefix = "form"
+
+// MaxFormSetForms is the upper limit of forms in formset, it protects from
+// sending huge TOTAL_FORMS value
+const MaxFormSetForms = 1000
+
+// FormSet is structure that holds the same form repeated many times, ie. line
+// items of an order. Every form in set has prefix made of formset's prefix and
+// form's index, so its fields are rendered as ie. "form-0-name". Number of
+// submitted forms is tracked in management form, rendered as hidden inputs.
+type FormSet struct {
+	// Base form which is copied for every form in set
+	Form *Form
+	// Prefix of all fields in formset, DefaultFormSetPrefix is used when empty
+	Prefix string
+
+	// Number of empty forms that are rendered after initial ones
+	Extra int
+	// Minimal number of forms that needs to be submitted
+	MinNum int
+	// Maximal number of forms that can be submitted, 0 means no limit
+	MaxNum int
+	// Add deletion checkbox to every form
+	CanDelete bool
+	// Add ordering field to every form
+	CanOrder bool
+
+	// Forms in set, they are created by SetInitial, IsValid or when formset is
+	// rendered for the first time
+	Forms []*Form
+
+	// Errors of whole set, errors of forms are kept by forms
+	Errors []*ValidationError
+
+	// Initial data for forms, every element is used for one form
+	InitialData []Data
+
+	// Information if form in set was skipped during validation, because it
+	// was deleted or was extra form that wasn't filled
+	deleted []bool
+	skipped []bool
+}
+
+// prefix returns formset's prefix
+func (fs *FormSet) prefix() string {
+	if fs.Prefix == "" {
+		return DefaultFormSetPrefix
+	}
+
+	return fs.Prefix
+}
+
+// formPrefix returns prefix of form with given index
+func (fs *FormSet) formPrefix(i int) string {
+	return fmt.Sprintf("%s-%d", fs.prefix(), i)
+}
+
+// managementName returns name of field in management form
+func (fs *FormSet) managementName(name string) string {
+	return fmt.Sprintf("%s-%s", fs.prefix(), name)
+}
+
+// newForm creates copy of base form with prefix of form with given index
+func (fs *FormSet) newForm(i int) *Form {
+	form := fs.Form.clone()
+	form.Prefix = fs.formPrefix(i)
+	if fs.CanOrder {
+		form.AddField(&Field{Name: OrderingFieldName, Label: "ORDER_LABEL", Type: &InputNumber{}})
+	}
+	if fs.CanDelete {
+		form.AddField(&Field{Name: DeletionFieldName, Label: "DELETE_LABEL", Type: &Checkbox{}})
+	}
+	if i < len(fs.InitialData) {
+		form.SetInitial(fs.InitialData[i])
+	}
+
+	return form
+}
+
+// initialForms returns number of forms with initial data
+func (fs *FormSet) initialForms() int {
+	if fs.MaxNum > 0 && len(fs.InitialData) > fs.MaxNum {
+		return fs.MaxNum
+	}
+
+	return len(fs.InitialData)
+}
+
+// buildForms creates given number of forms
+func (fs *FormSet) buildForms(total int) {
+	fs.Forms = make([]*Form, total)
+	for i := range fs.Forms {
+		fs.Forms[i] = fs.newForm(i)
+	}
+	fs.deleted = make([]bool, total)
+	fs.skipped = make([]bool, total)
+}
+
+// ensureForms creates initial and extra forms if they weren't created yet
+func (fs *FormSet) ensureForms() {
+	if fs.Forms != nil {
+		return
+	}
+
+	total := len(fs.InitialData) + fs.Extra
+	if fs.MaxNum > 0 && total > fs.MaxNum {
+		total = fs.MaxNum
+	}
+	if total < fs.MinNum {
+		total = fs.MinNum
+	}
+	fs.buildForms(total)
+}
+
+// SetInitial sets initial data, one element per form, and recreates forms
+func (fs *FormSet) SetInitial(data []Data) {
+	fs.InitialData = data
+	fs.Forms = nil
+	fs.ensureForms()
+}
+
+// IsValid validates management form and every form in set. Deleted forms and
+// extra forms that weren't changed from their initial values are skipped.
+func (fs *FormSet) IsValid(data url.Values) bool {
+	fs.Errors = []*ValidationError{}
+
+	total, errTotal := strconv.Atoi(data.Get(fs.managementName(TotalFormsName)))
+	_, errInitial := strconv.Atoi(data.Get(fs.managementName(InitialFormsName)))
+	if errTotal != nil || errInitial != nil || total < 0 {
+		fs.Forms = nil
+		fs.Errors = append(fs.Errors, NewValidationError("MANAGEMENT_FORM_MISSING", "", nil))
+		return false
+	}
+
+	absoluteMax := MaxFormSetForms
+	if fs.MaxNum > 0 && fs.MaxNum < absoluteMax {
+		absoluteMax = fs.MaxNum
+	}
+	if total > absoluteMax {
+		fs.Errors = append(fs.Errors, NewValidationError("TOO_MANY_FORMS", "", Params{"Max": absoluteMax}))
+		total = absoluteMax
+	}
+
+	fs.buildForms(total)
+	isValid := !fs.HasErrors()
+	filled := 0
+	for i, form := range fs.Forms {
+		if fs.CanDelete && fs.isDeleted(i, data) {
+			fs.deleted[i] = true
+			continue
+		}
+		if i >= fs.initialForms() && !fs.hasData(i, data) {
+			fs.skipped[i] = true
+			continue
+		}
+
+		filled++
+		if !form.IsValid(data) {
+			isValid = false
+		}
+	}
+
+	if filled < fs.MinNum {
+		fs.Errors = append(fs.Errors, NewValidationError("TOO_FEW_FORMS", "", Params{"Min": fs.MinNum}))
+		isValid = false
+	}
+
+	return isValid
+}
+
+// isDeleted checks if form with given index was marked for deletion
+func (fs *FormSet) isDeleted(i int, data url.Values) bool {
+	values := data[fs.formPrefix(i)+"-"+DeletionFieldName]
+	return len(values) > 0 && values[0] != ""
+}
+
+// hasData checks if any of fields of form with given index was changed,
+// submitted values equal to initial ones, ie. rendered into extra form, and
+// empty values don't count as changes
+func (fs *FormSet) hasData(i int, data url.Values) bool {
+	for name, field := range fs.Forms[i].Fields {
+		if name == DeletionFieldName || name == OrderingFieldName {
+			continue
+		}
+		submitted := nonEmptyValues(data[field.HTMLName()])
+		initial := nonEmptyValues(field.initialValues())
+		if !reflect.DeepEqual(submitted, initial) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// activeForms returns indexes of forms that were validated
+func (fs *FormSet) activeForms() []int {
+	var indexes []int
+	for i := range fs.Forms {
+		if fs.deleted[i] || fs.skipped[i] {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+
+	return indexes
+}
+
+// CleanedData returns cleaned data of every valid form, without deleted and
+// empty extra forms. When CanOrder is set data is sorted by ordering field,
+// forms without order are put at the end.
+func (fs *FormSet) CleanedData() []Data {
+	type orderedData struct {
+		order interface{}
+		data  Data
+	}
+
+	var items []orderedData
+	for _, i := range fs.activeForms() {
+		form := fs.Forms[i]
+		if form.CleanedData == nil {
+			continue
+		}
+
+		data := Data{}
+		for name, value := range form.CleanedData {
+			data[name] = value
+		}
+		order := data[OrderingFieldName]
+		delete(data, OrderingFieldName)
+		delete(data, DeletionFieldName)
+		items = append(items, orderedData{order, data})
+	}
+
+	if fs.CanOrder {
+		sort.SliceStable(items, func(i, j int) bool {
+			oi, iok := toFloat(items[i].order)
+			oj, jok := toFloat(items[j].order)
+			if iok && jok {
+				return oi < oj
+			}
+			return iok && !jok
+		})
+	}
+
+	cleaned := make([]Data, len(items))
+	for i, item := range items {
+		cleaned[i] = item.data
+	}
+
+	return cleaned
+}
+
+// DeletedForms returns forms that were marked for deletion
+func (fs *FormSet) DeletedForms() []*Form {
+	var forms []*Form
+	for i, deleted := range fs.deleted {
+		if deleted {
+			forms = append(forms, fs.Forms[i])
+		}
+	}
+
+	return forms
+}
+
+// HasErrors returns information if there are formset level errors
+func (fs *FormSet) HasErrors() bool {
+	return len(fs.Errors) > 0
+}
+
+// AddError adds new formset level error
+func (fs *FormSet) AddError(error string) {
+	fs.Errors = append(fs.Errors, &ValidationError{Message: error})
+}
+
+// RenderErrors render formset level errors as list (<ul>) with class "errors"
+func (fs *FormSet) RenderErrors() template.HTML {
+	return renderErrors(fs.Errors, fs.Form.translator())
+}
+
+// ManagementForm renders hidden inputs with number of forms in formset, it
+// needs to be rendered inside form tag
+func (fs *FormSet) ManagementForm() template.HTML {
+	fs.ensureForms()
+
+	values := []struct {
+		name  string
+		value int
+	}{
+		{TotalFormsName, len(fs.Forms)},
+		{InitialFormsName, fs.initialForms()},
+		{MinNumFormsName, fs.MinNum},
+		{MaxNumFormsName, fs.MaxNum},
+	}
+
+	rendered := ""
+	for _, v := range values {
+		rendered += string(renderInput(
+			nil, fs.managementName(v.name), "hidden", noUseAttrs, []string{strconv.Itoa(v.value)},
+		)) + "\n"
+	}
+
+	return template.HTML(rendered)
+}
+
+// Render renders management form, formset errors and all forms, with their
+// hidden fields
+func (fs *FormSet) Render() template.HTML {
+	rendered := fs.ManagementForm() + fs.RenderErrors()
+	for _, form := range fs.Forms {
+		rendered += form.RenderHidden() + form.Render()
+	}
+
+	return rendered
+}
+
+// toFloat converts cleaned number to float
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	return 0, false
+}
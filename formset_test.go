@@ -0,0 +1,196 @@
+package forms
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newItemFormSet() *FormSet {
+	return &FormSet{
+		Form: NewOrdered([]*Field{
+			{Name: "name", Validators: []Validator{&Required{}}},
+			{Name: "quantity", Type: &InputNumber{}},
+		}, nil),
+		Extra: 2,
+	}
+}
+
+func TestFormSetRender(t *testing.T) {
+	fs := newItemFormSet()
+	fs.SetInitial([]Data{{"name": "Spam", "quantity": 2}})
+
+	assert.Len(t, fs.Forms, 3)
+	assert.Equal(t, fs.Forms[1].Prefix, "form-1")
+	assert.Equal(t, fs.Forms[0].Fields["name"].InitialValue, "Spam")
+
+	rendered := fs.Render()
+	assert.Contains(t, rendered, `<input name="form-TOTAL_FORMS" type="hidden" id="f_form-TOTAL_FORMS" value="3" />`)
+	assert.Contains(t, rendered, `<input name="form-INITIAL_FORMS" type="hidden" id="f_form-INITIAL_FORMS" value="1" />`)
+	assert.Contains(t, rendered, `<input name="form-MIN_NUM_FORMS" type="hidden" id="f_form-MIN_NUM_FORMS" value="0" />`)
+	assert.Contains(t, rendered, `<input name="form-0-name" type="input" id="f_form-0-name" value="Spam" />`)
+	assert.Contains(t, rendered, `<input name="form-2-quantity" type="number" id="f_form-2-quantity" />`)
+	assert.NotContains(t, rendered, `form-3-`)
+
+	fs = newItemFormSet()
+	fs.MaxNum = 1
+	fs.Prefix = "items"
+	assert.Contains(t, fs.ManagementForm(), `<input name="items-TOTAL_FORMS" type="hidden" id="f_items-TOTAL_FORMS" value="1" />`)
+}
+
+func TestFormSetRenderHidden(t *testing.T) {
+	fs := &FormSet{
+		Form: NewOrdered([]*Field{
+			{Name: "id", Type: &InputHidden{}},
+			{Name: "name"},
+		}, nil),
+	}
+	fs.SetInitial([]Data{{"id": 7, "name": "Spam"}})
+
+	rendered := fs.Render()
+	assert.Contains(t, rendered, `<input name="form-0-id" type="hidden" id="f_form-0-id" value="7" />`)
+	assert.Contains(t, rendered, `<input name="form-0-name" type="input" id="f_form-0-name" value="Spam" />`)
+
+	data := url.Values{
+		"form-TOTAL_FORMS":   {"1"},
+		"form-INITIAL_FORMS": {"1"},
+		"form-0-id":          {"7"},
+		"form-0-name":        {"Eggs"},
+	}
+	assert.True(t, fs.IsValid(data))
+	assert.Equal(t, fs.CleanedData(), []Data{{"id": "7", "name": "Eggs"}})
+}
+
+func TestFormSetIsValid(t *testing.T) {
+	fs := newItemFormSet()
+	data := url.Values{
+		"form-TOTAL_FORMS":   {"3"},
+		"form-INITIAL_FORMS": {"0"},
+		"form-0-name":        {"Spam"},
+		"form-0-quantity":    {"2"},
+		"form-1-name":        {"Eggs"},
+	}
+
+	assert.True(t, fs.IsValid(data))
+	assert.Len(t, fs.Forms, 3)
+	assert.Equal(t, fs.Forms[0].CleanedData, Data{"name": "Spam", "quantity": int64(2)})
+	assert.Equal(t, fs.CleanedData(), []Data{
+		{"name": "Spam", "quantity": int64(2)},
+		{"name": "Eggs", "quantity": nil},
+	}, "Empty extra form should be skipped")
+
+	data.Set("form-2-quantity", "3")
+	assert.False(t, fs.IsValid(data))
+	assert.True(t, fs.Forms[2].Fields["name"].HasErrors())
+	assert.False(t, fs.HasErrors())
+}
+
+func TestFormSetManagementForm(t *testing.T) {
+	fs := newItemFormSet()
+	assert.False(t, fs.IsValid(url.Values{"form-0-name": {"Spam"}}))
+	assert.Equal(t, errorCodes(fs.Errors), []string{"MANAGEMENT_FORM_MISSING"})
+	assert.Contains(t, fs.RenderErrors(), fs.Errors[0].Error())
+
+	assert.False(t, fs.IsValid(url.Values{"form-TOTAL_FORMS": {"x"}, "form-INITIAL_FORMS": {"0"}}))
+	assert.Equal(t, errorCodes(fs.Errors), []string{"MANAGEMENT_FORM_MISSING"})
+
+	assert.False(t, fs.IsValid(url.Values{"form-TOTAL_FORMS": {"100000"}, "form-INITIAL_FORMS": {"0"}}))
+	assert.Equal(t, fs.Errors, []*ValidationError{NewValidationError("TOO_MANY_FORMS", "", Params{"Max": MaxFormSetForms})})
+	assert.Len(t, fs.Forms, MaxFormSetForms)
+}
+
+func TestFormSetMinMax(t *testing.T) {
+	fs := newItemFormSet()
+	fs.MinNum = 2
+	fs.MaxNum = 2
+
+	data := url.Values{
+		"form-TOTAL_FORMS":   {"2"},
+		"form-INITIAL_FORMS": {"0"},
+		"form-0-name":        {"Spam"},
+	}
+	assert.False(t, fs.IsValid(data))
+	assert.Equal(t, fs.Errors, []*ValidationError{NewValidationError("TOO_FEW_FORMS", "", Params{"Min": 2})})
+
+	data.Set("form-TOTAL_FORMS", "3")
+	data.Set("form-1-name", "Ham")
+	data.Set("form-2-name", "Eggs")
+	assert.False(t, fs.IsValid(data))
+	assert.Equal(t, fs.Errors, []*ValidationError{NewValidationError("TOO_MANY_FORMS", "", Params{"Max": 2})})
+
+	data.Set("form-TOTAL_FORMS", "2")
+	assert.True(t, fs.IsValid(data))
+	assert.Len(t, fs.CleanedData(), 2)
+}
+
+func TestFormSetDeleteAndOrder(t *testing.T) {
+	fs := newItemFormSet()
+	fs.CanDelete = true
+	fs.CanOrder = true
+	fs.SetInitial([]Data{{"name": "Spam"}, {"name": "Ham"}})
+
+	rendered := string(fs.Render())
+	assert.Contains(t, rendered, `name="form-0-DELETE" type="checkbox"`)
+	assert.Contains(t, rendered, `name="form-1-ORDER" type="number"`)
+	assert.True(t, strings.Index(rendered, "form-0-ORDER") < strings.Index(rendered, "form-0-DELETE"))
+
+	data := url.Values{
+		"form-TOTAL_FORMS":   {"4"},
+		"form-INITIAL_FORMS": {"2"},
+		"form-0-name":        {"Spam"},
+		"form-0-ORDER":       {"3"},
+		"form-1-name":        {""},
+		"form-1-DELETE":      {"on"},
+		"form-2-name":        {"Eggs"},
+		"form-2-ORDER":       {"1"},
+		"form-3-name":        {"Bacon"},
+	}
+
+	assert.True(t, fs.IsValid(data), "Deleted forms shouldn't be validated")
+	assert.Equal(t, fs.DeletedForms(), []*Form{fs.Forms[1]})
+	assert.Equal(t, fs.CleanedData(), []Data{
+		{"name": "Eggs", "quantity": nil},
+		{"name": "Spam", "quantity": nil},
+		{"name": "Bacon", "quantity": nil},
+	})
+}
+
+func TestFormSetInitialFormsAreValidated(t *testing.T) {
+	fs := newItemFormSet()
+	data := url.Values{
+		"form-TOTAL_FORMS":   {"1"},
+		"form-INITIAL_FORMS": {"1"},
+	}
+	fs.SetInitial([]Data{{"name": "Spam"}})
+
+	assert.False(t, fs.IsValid(data), "Initial forms can't be skipped")
+}
+
+func TestFormSetUnchangedExtraForms(t *testing.T) {
+	fs := &FormSet{
+		Form: NewOrdered([]*Field{
+			{Name: "name", Validators: []Validator{&Required{}}},
+			{Name: "qty", Type: &InputNumber{}, InitialValue: 1},
+		}, nil),
+		Extra: 2,
+	}
+	data := url.Values{
+		"form-TOTAL_FORMS":   {"2"},
+		"form-INITIAL_FORMS": {"0"},
+		"form-0-name":        {"Spam"},
+		"form-0-qty":         {"1"},
+		"form-1-qty":         {"1"},
+	}
+
+	assert.True(t, fs.IsValid(data), "Extra form with only initial values should be skipped")
+	assert.Equal(t, fs.CleanedData(), []Data{{"name": "Spam", "qty": int64(1)}})
+
+	data.Set("form-1-qty", "2")
+	assert.False(t, fs.IsValid(data))
+	assert.Equal(t, errorCodes(fs.Forms[1].Fields["name"].Errors), []string{"REQUIRED"})
+
+	data.Set("form-1-qty", "")
+	assert.False(t, fs.IsValid(data), "Cleared initial value is a change")
+}
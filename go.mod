@@ -1,5 +1,5 @@
 module github.com/Alkemic/forms
 
-go 1.15
+go 1.16
 
 require github.com/stretchr/testify v1.6.1
@@ -2,13 +2,15 @@ package forms
 
 import (
 	"fmt"
+	"html"
 	"html/template"
 	"reflect"
+	"sort"
 )
 
 // isSlice if given value is slice
 func isSlice(value interface{}) bool {
-	return reflect.TypeOf(value).Kind() == reflect.Slice
+	return value != nil && reflect.TypeOf(value).Kind() == reflect.Slice
 }
 
 // valueInSlice if given string is in slice
@@ -22,6 +24,57 @@ func valueInSlice(s string, vs []string) bool {
 	return false
 }
 
+// nonEmptyValues returns sorted copy of given values without empty ones
+func nonEmptyValues(vs []string) []string {
+	var result []string
+	for _, v := range vs {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// removeString returns slice without given string
+func removeString(vs []string, s string) []string {
+	result := make([]string, 0, len(vs))
+	for _, v := range vs {
+		if v != s {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// clampIndex returns index limited to range from 0 to max
+func clampIndex(index, max int) int {
+	if index < 0 {
+		return 0
+	}
+	if index > max {
+		return max
+	}
+
+	return index
+}
+
+// copyAttributes returns shallow copy of attributes
+func copyAttributes(attrs Attributes) Attributes {
+	if attrs == nil {
+		return nil
+	}
+
+	copied := make(Attributes, len(attrs))
+	for k, v := range attrs {
+		copied[k] = v
+	}
+
+	return copied
+}
+
 // prepareAttributes prepares attributes to use in HTML tags
 func prepareAttributes(attrs Attributes, noUse []string) string {
 	attributes := ""
@@ -35,8 +88,10 @@ func prepareAttributes(attrs Attributes, noUse []string) string {
 	return attributes
 }
 
-// renderInput returns rendered input HTML tag
+// renderInput returns rendered input HTML tag, given attributes are not
+// modified
 func renderInput(as Attributes, n, t string, noUse, vs []string) template.HTML {
+	as = copyAttributes(as)
 	if as == nil {
 		as = Attributes{}
 	}
@@ -54,6 +109,140 @@ func renderInput(as Attributes, n, t string, noUse, vs []string) template.HTML {
 	return template.HTML(fmt.Sprintf("<input name=\"%s\" type=\"%s\"%s />", n, t, attributes))
 }
 
+// renderInputs returns inputs of given type, one for every value, ids of
+// inputs are field's id followed by index of value. Field's attributes are not
+// modified.
+func renderInputs(f *Field, t string, vs []string) template.HTML {
+	id := fmt.Sprintf("f_%s", f.HTMLName())
+	if value, ok := f.Attributes["id"]; ok {
+		id = fmt.Sprint(value)
+	}
+
+	rendered := ""
+	for i, v := range vs {
+		attrs := copyAttributes(f.Attributes)
+		if attrs == nil {
+			attrs = Attributes{}
+		}
+		attrs["id"] = fmt.Sprintf("%s_%d", id, i)
+		rendered += string(renderInput(attrs, f.HTMLName(), t, noUseAttrs, []string{v})) + "\n"
+	}
+
+	return template.HTML(rendered)
+}
+
+// renderChoiceInputs returns inputs of given type (radio or checkbox), one
+// for every choice, wrapped in labels. Inputs of given values are checked,
+// values and labels are escaped, like in options of select. Field's attributes
+// are not modified.
+func renderChoiceInputs(f *Field, t string, cs []Choice, vs []string) template.HTML {
+	rendered := ""
+	for _, c := range flattenChoices(cs) {
+		attrs := copyAttributes(f.Attributes)
+		if attrs == nil {
+			attrs = Attributes{}
+		}
+		attrs["id"] = fmt.Sprintf("c_%s_%s", f.HTMLName(), c.Value)
+		if valueInSlice(c.Value, vs) {
+			attrs["checked"] = "checked"
+		}
+		if c.Disabled {
+			attrs["disabled"] = "disabled"
+		}
+
+		rendered = rendered + fmt.Sprintf(
+			"<label for=\"c_%s_%s\"><input name=\"%s\" type=\"%s\"%s value=\"%s\" /> %s</label>\n",
+			f.HTMLName(), c.Value, f.HTMLName(), t,
+			prepareAttributes(attrs, noUseAttrs), html.EscapeString(c.Value), html.EscapeString(c.Label),
+		)
+	}
+
+	return template.HTML(rendered)
+}
+
+// withEmptyChoice returns choices preceded by choice with empty value, label
+// of the choice is translated with translator of field's form
+func withEmptyChoice(f *Field, cs []Choice, label string) []Choice {
+	if label == "" {
+		label = "EMPTY_CHOICE_LABEL"
+	}
+
+	empty := Choice{Value: "", Label: translate(f.form.translator(), label)}
+	return append([]Choice{empty}, cs...)
+}
+
+// firstValue returns slice with the first of given values, it's used by
+// single value types
+func firstValue(vs []string) []string {
+	if len(vs) > 1 {
+		return vs[:1]
+	}
+
+	return vs
+}
+
+// renderSelect returns rendered select HTML tag with options made from given
+// choices, options with given values are selected. Attributes are not modified.
+func renderSelect(as Attributes, n string, noUse []string, cs []Choice, vs []string) template.HTML {
+	as = copyAttributes(as)
+	if as == nil {
+		as = Attributes{}
+	}
+
+	if _, ok := as["id"]; !ok {
+		as["id"] = fmt.Sprintf("f_%s", n)
+	}
+
+	return template.HTML(fmt.Sprintf(
+		"<select name=\"%s\"%s>\n%s</select>", n, prepareAttributes(as, noUse), renderOptions(cs, vs),
+	))
+}
+
+// renderOptions returns choices rendered as options, groups of choices are
+// rendered as optgroup
+func renderOptions(cs []Choice, vs []string) string {
+	options := ""
+	for _, c := range cs {
+		attributes := ""
+		if c.Disabled {
+			attributes = " disabled=\"disabled\""
+		}
+
+		if len(c.Choices) > 0 {
+			options += fmt.Sprintf(
+				"<optgroup label=\"%s\"%s>\n%s</optgroup>\n",
+				html.EscapeString(c.Label), attributes, renderOptions(c.Choices, vs),
+			)
+			continue
+		}
+
+		if valueInSlice(c.Value, vs) {
+			attributes = " selected=\"selected\"" + attributes
+		}
+		options += fmt.Sprintf(
+			"<option value=\"%s\"%s>%s</option>\n",
+			html.EscapeString(c.Value), attributes, html.EscapeString(c.Label),
+		)
+	}
+
+	return options
+}
+
+// renderErrors renders messages of errors as list (<ul>) with class "errors",
+// messages are taken from given translator
+func renderErrors(errors []*ValidationError, t Translator) template.HTML {
+	if len(errors) == 0 {
+		return ""
+	}
+
+	rendered := ""
+	for _, err := range errors {
+		rendered += fmt.Sprintf("<li>%s</li>\n", html.EscapeString(err.Translate(t)))
+	}
+
+	return template.HTML(fmt.Sprintf("<ul class=\"errors\">\n%s</ul>", rendered))
+}
+
 func anyToString(v interface{}) (string, bool) {
 	switch v.(type) {
 	default:
@@ -0,0 +1,139 @@
+package forms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+)
+
+// jsonKind returns name of JSON type that is expected by field type for a
+// single value
+func jsonKind(t Type) string {
+	switch t.(type) {
+	case *Checkbox:
+		return "boolean"
+	case *InputNumber:
+		return "number"
+	}
+
+	return "string"
+}
+
+// jsonScalarToString converts single JSON value to string, as it would be
+// sent by HTML form
+func jsonScalarToString(value interface{}, kind string) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, kind == "string"
+	case json.Number:
+		return value.String(), kind == "number"
+	case bool:
+		if value {
+			return "on", kind == "boolean"
+		}
+		return "", kind == "boolean"
+	}
+
+	return "", false
+}
+
+// jsonToValues converts JSON value to values of field with given type. Null
+// means no value, arrays are accepted only by multi value types.
+func jsonToValues(value interface{}, t Type) ([]string, bool) {
+	kind := jsonKind(t)
+	switch value := value.(type) {
+	case nil:
+		return nil, true
+	case []interface{}:
+		if !t.IsMultiValue() {
+			return nil, false
+		}
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			s, ok := jsonScalarToString(v, kind)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+		return values, true
+	}
+
+	s, ok := jsonScalarToString(value, kind)
+	if !ok || (kind == "boolean" && s == "") {
+		return nil, ok
+	}
+
+	return []string{s}, true
+}
+
+// IsValidJSON decodes JSON object from given reader and validates it. Keys of
+// object are field names (with form's prefix), values are converted to match
+// field's type: strings for text fields, numbers for InputNumber, booleans for
+// Checkbox and arrays for multi value types, null means no value. Values with
+// wrong JSON type are reported as field errors, if data isn't JSON object form
+// gets an error.
+func (f *Form) IsValidJSON(r io.Reader) bool {
+	return f.isValidJSON(context.Background(), r)
+}
+
+// isValidJSON decodes and validates JSON object, context is passed to
+// validators
+func (f *Form) isValidJSON(ctx context.Context, r io.Reader) bool {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil || object == nil {
+		if err == nil {
+			err = errors.New("forms: JSON object expected")
+		}
+		return f.requestError(err)
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		if err == nil {
+			err = errors.New("forms: unexpected data after JSON object")
+		}
+		return f.requestError(err)
+	}
+
+	s := submission{values: url.Values{}, errors: map[string][]*ValidationError{}}
+	for _, name := range f.fieldNames() {
+		field := f.Fields[name]
+		if field.Type == nil {
+			field.Type = &Input{}
+		}
+		value, ok := object[field.HTMLName()]
+		if !ok {
+			continue
+		}
+		if _, isFile := field.Type.(FileType); isFile {
+			continue
+		}
+
+		values, ok := jsonToValues(value, field.Type)
+		if !ok {
+			kind := jsonKind(field.Type)
+			if field.Type.IsMultiValue() {
+				kind = "array"
+			}
+			err := NewValidationError("INCORRECT_JSON_TYPE", "", Params{"Type": kind})
+			err.Field = name
+			s.errors[name] = []*ValidationError{err}
+			continue
+		}
+		if values != nil {
+			s.values[field.HTMLName()] = values
+		}
+	}
+
+	if f.CSRF != nil {
+		if token, ok := object[f.csrfFieldName()].(string); ok {
+			s.values.Set(f.csrfFieldName(), token)
+		}
+	}
+
+	return f.isValid(ctx, s)
+}
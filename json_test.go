@@ -0,0 +1,83 @@
+package forms
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newJSONForm() *Form {
+	return New(map[string]*Field{
+		"name":   &Field{Validators: []Validator{&Required{}}},
+		"age":    &Field{Type: &InputNumber{}},
+		"accept": &Field{Type: &Checkbox{}},
+		"food":   &Field{Type: &CheckboxMultiple{}},
+	}, nil)
+}
+
+func TestFormIsValidJSON(t *testing.T) {
+	f := newJSONForm()
+
+	assert.True(t, f.IsValidJSON(strings.NewReader(
+		`{"name": "Foo", "age": 12.5, "accept": true, "food": ["pizza", "pasta"], "unknown": {}}`,
+	)))
+	assert.Equal(t, f.CleanedData, Data{
+		"name": "Foo", "age": 12.5, "accept": true, "food": []string{"pizza", "pasta"},
+	})
+
+	assert.True(t, f.IsValidJSON(strings.NewReader(`{"name": "Foo", "age": null, "accept": false, "food": "pizza"}`)))
+	assert.Equal(t, f.CleanedData, Data{
+		"name": "Foo", "age": nil, "accept": false, "food": []string{"pizza"},
+	})
+
+	assert.False(t, f.IsValidJSON(strings.NewReader(`{"name": null}`)))
+	assert.True(t, f.Fields["name"].HasErrors())
+}
+
+func TestFormIsValidJSONSameAsHTML(t *testing.T) {
+	jsonForm := newJSONForm()
+	htmlForm := newJSONForm()
+
+	assert.True(t, jsonForm.IsValidJSON(strings.NewReader(`{"name": "Foo", "age": 30, "accept": true, "food": ["pizza"]}`)))
+	assert.True(t, htmlForm.IsValid(url.Values{"name": {"Foo"}, "age": {"30"}, "accept": {"on"}, "food": {"pizza"}}))
+	assert.Equal(t, jsonForm.CleanedData, htmlForm.CleanedData)
+}
+
+func TestFormIsValidJSONTypeErrors(t *testing.T) {
+	f := newJSONForm()
+
+	assert.False(t, f.IsValidJSON(strings.NewReader(`{"name": 1, "age": "12", "accept": "yes", "food": [["pizza"]]}`)))
+	assert.Equal(t, f.Fields["name"].Errors, []*ValidationError{{Code: "INCORRECT_JSON_TYPE", Field: "name", Params: Params{"Type": "string"}}})
+	assert.Equal(t, f.Fields["age"].Errors, []*ValidationError{{Code: "INCORRECT_JSON_TYPE", Field: "age", Params: Params{"Type": "number"}}})
+	assert.Equal(t, f.Fields["accept"].Errors, []*ValidationError{{Code: "INCORRECT_JSON_TYPE", Field: "accept", Params: Params{"Type": "boolean"}}})
+	assert.Equal(t, f.Fields["food"].Errors, []*ValidationError{{Code: "INCORRECT_JSON_TYPE", Field: "food", Params: Params{"Type": "array"}}})
+	assert.Nil(t, f.CleanedData)
+
+	assert.False(t, f.IsValidJSON(strings.NewReader(`{"name": ["Foo", "Bar"]}`)))
+	assert.Equal(t, f.Fields["name"].Errors, []*ValidationError{{Code: "INCORRECT_JSON_TYPE", Field: "name", Params: Params{"Type": "string"}}})
+
+	assert.False(t, f.IsValidJSON(strings.NewReader(`{"name": {"first": "Foo"}}`)))
+	assert.Equal(t, f.Fields["name"].Errors, []*ValidationError{{Code: "INCORRECT_JSON_TYPE", Field: "name", Params: Params{"Type": "string"}}})
+}
+
+func TestFormIsValidJSONMalformed(t *testing.T) {
+	f := newJSONForm()
+
+	for _, body := range []string{``, `{"name": `, `["Foo"]`, `null`, `"Foo"`,
+		`{"name": "Foo"} trailing`, `{"name": "Foo"}{"name": "Bar"}`, `{"name": "Foo"} 1`} {
+		assert.False(t, f.IsValidJSON(strings.NewReader(body)), body)
+		assert.Equal(t, errorCodes(f.Errors), []string{"REQUEST_INVALID"}, body)
+	}
+
+	assert.True(t, f.IsValidJSON(strings.NewReader("{\"name\": \"Foo\"}\n  ")), "Trailing whitespace should be allowed")
+}
+
+func TestFormIsValidJSONPrefix(t *testing.T) {
+	f := newJSONForm()
+	f.Prefix = "p"
+
+	assert.True(t, f.IsValidJSON(strings.NewReader(`{"p-name": "Foo"}`)))
+	assert.Equal(t, f.CleanedData["name"], "Foo")
+}
@@ -0,0 +1,279 @@
+package forms
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// catalogLang returns language of catalog taken from file name, ie. "pl" for
+// "locale/pl.po"
+func catalogLang(name string) string {
+	base := path.Base(name)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// poStatement is single keyword with its value read from PO file, empty
+// keyword separates entries
+type poStatement struct {
+	keyword string
+	index   int
+	value   string
+	fuzzy   bool
+}
+
+// readPO splits PO file into statements, strings continued in following lines
+// are joined and comments are skipped
+func readPO(fsys fs.FS, name string) ([]poStatement, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	statements := []poStatement{}
+	fuzzy := false
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			statements = append(statements, poStatement{})
+			continue
+		case strings.HasPrefix(line, "#,"):
+			fuzzy = fuzzy || strings.Contains(line, "fuzzy")
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, `"`):
+			value, err := strconv.Unquote(line)
+			last := len(statements) - 1
+			if err != nil || last < 0 || statements[last].keyword == "" {
+				return nil, fmt.Errorf("forms: %s:%d: unexpected string %s", name, lineNo, line)
+			}
+			statements[last].value += value
+			continue
+		}
+
+		keyword, quoted := line, ""
+		if i := strings.IndexAny(line, " \t"); i > 0 {
+			keyword, quoted = line[:i], strings.TrimSpace(line[i:])
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("forms: %s:%d: incorrect string %s", name, lineNo, quoted)
+		}
+
+		statement := poStatement{keyword: keyword, value: value, fuzzy: fuzzy}
+		if strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]") {
+			statement.keyword = "msgstr"
+			statement.index, err = strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil || statement.index < 0 {
+				return nil, fmt.Errorf("forms: %s:%d: incorrect keyword %s", name, lineNo, keyword)
+			}
+		}
+		switch statement.keyword {
+		case "msgctxt", "msgid", "msgid_plural", "msgstr":
+		default:
+			return nil, fmt.Errorf("forms: %s:%d: unknown keyword %s", name, lineNo, keyword)
+		}
+		statements = append(statements, statement)
+		fuzzy = false
+	}
+
+	return statements, scanner.Err()
+}
+
+// poEntry is single message read from PO file
+type poEntry struct {
+	id       string
+	idPlural string
+	strs     []string
+	fuzzy    bool
+}
+
+// addPOEntry adds translated message to catalog, entry with empty id is
+// header, which contains language and plural rule
+func (c *Catalog) addPOEntry(entry *poEntry) error {
+	if entry.id == "" {
+		if len(entry.strs) == 0 {
+			return nil
+		}
+		for _, line := range strings.Split(entry.strs[0], "\n") {
+			parts := strings.SplitN(line, ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			value := strings.TrimSpace(parts[1])
+			switch strings.TrimSpace(parts[0]) {
+			case "Language":
+				if value != "" {
+					c.Lang = value
+				}
+			case "Plural-Forms":
+				rule, err := ParsePluralRule(value)
+				if err != nil {
+					return err
+				}
+				c.PluralRule = rule
+			}
+		}
+		return nil
+	}
+
+	if entry.fuzzy {
+		return nil
+	}
+	for _, str := range entry.strs {
+		if str == "" {
+			return nil
+		}
+	}
+	if entry.idPlural != "" {
+		c.Plurals[entry.id] = entry.strs
+	} else if len(entry.strs) > 0 {
+		c.Messages[entry.id] = entry.strs[0]
+	}
+
+	return nil
+}
+
+// LoadPO reads catalog from GNU gettext PO file. Message ids are keys of
+// messages (ie. "REQUIRED"), plural forms are chosen using Plural-Forms
+// header. Language is taken from Language header or from file name.
+// Untranslated and fuzzy messages are skipped.
+// Example
+//     //go:embed locale
+//     var locale embed.FS
+//
+//     catalog, err := forms.LoadPO(locale, "locale/pl.po")
+func LoadPO(fsys fs.FS, name string) (*Catalog, error) {
+	statements, err := readPO(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &Catalog{
+		Lang:     catalogLang(name),
+		Messages: map[string]string{},
+		Plurals:  map[string][]string{},
+	}
+
+	entry := &poEntry{}
+	for _, statement := range statements {
+		startsEntry := statement.keyword == "msgctxt" || statement.keyword == "msgid"
+		if statement.keyword == "" || (startsEntry && len(entry.strs) > 0) {
+			if err := catalog.addPOEntry(entry); err != nil {
+				return nil, fmt.Errorf("forms: %s: %v", name, err)
+			}
+			entry = &poEntry{}
+		}
+
+		switch statement.keyword {
+		case "msgid":
+			entry.id = statement.value
+		case "msgid_plural":
+			entry.idPlural = statement.value
+		case "msgstr":
+			for len(entry.strs) <= statement.index {
+				entry.strs = append(entry.strs, "")
+			}
+			entry.strs[statement.index] = statement.value
+		}
+		entry.fuzzy = entry.fuzzy || statement.fuzzy
+	}
+	if err := catalog.addPOEntry(entry); err != nil {
+		return nil, fmt.Errorf("forms: %s: %v", name, err)
+	}
+
+	return catalog, nil
+}
+
+// LoadJSON reads catalog from flat JSON object, where keys are keys of
+// messages and values are messages, or arrays of plural forms. Language is
+// taken from file name, ie. "pl" for "locale/pl.json".
+// Example
+//     {
+//         "REQUIRED": "To pole nie może być puste",
+//         "INCORRECT_MIN_LENGTH": [
+//             "Wartość \"{Value}\" musi mieć co najmniej {Min} znak",
+//             "Wartość \"{Value}\" musi mieć co najmniej {Min} znaki",
+//             "Wartość \"{Value}\" musi mieć co najmniej {Min} znaków"
+//         ]
+//     }
+func LoadJSON(fsys fs.FS, name string) (*Catalog, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages map[string]interface{}
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("forms: %s: %v", name, err)
+	}
+
+	catalog := &Catalog{
+		Lang:     catalogLang(name),
+		Messages: map[string]string{},
+		Plurals:  map[string][]string{},
+	}
+	for key, value := range messages {
+		switch value := value.(type) {
+		case string:
+			catalog.Messages[key] = value
+		case []interface{}:
+			forms := make([]string, len(value))
+			for i, form := range value {
+				s, ok := form.(string)
+				if !ok {
+					return nil, fmt.Errorf("forms: %s: plural forms of %q must be strings", name, key)
+				}
+				forms[i] = s
+			}
+			catalog.Plurals[key] = forms
+		default:
+			return nil, fmt.Errorf("forms: %s: message %q must be string or array of strings", name, key)
+		}
+	}
+
+	return catalog, nil
+}
+
+// LoadCatalogs reads all PO (".po") and JSON (".json") files matching given
+// pattern and registers them, catalogs replace registered ones with the same
+// language.
+// Example
+//     //go:embed locale/*.po
+//     var locale embed.FS
+//
+//     if err := forms.LoadCatalogs(locale, "locale/*.po"); err != nil {
+//         log.Fatal(err)
+//     }
+func LoadCatalogs(fsys fs.FS, pattern string) error {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		var catalog *Catalog
+		switch path.Ext(name) {
+		case ".po":
+			catalog, err = LoadPO(fsys, name)
+		case ".json":
+			catalog, err = LoadJSON(fsys, name)
+		default:
+			err = fmt.Errorf("forms: %s: unsupported catalog format", name)
+		}
+		if err != nil {
+			return err
+		}
+		RegisterCatalog(catalog)
+	}
+
+	return nil
+}
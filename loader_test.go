@@ -0,0 +1,135 @@
+package forms
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPO = `# Polish translation
+#, fuzzy
+msgid ""
+msgstr ""
+"Language: pl_PL\n"
+"Plural-Forms: nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && "
+"(n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#: validators.go
+msgid "REQUIRED"
+msgstr "To pole jest wymagane"
+
+msgid "INCORRECT_MIN_LENGTH"
+msgid_plural "INCORRECT_MIN_LENGTH"
+msgstr[0] "Co najmniej {Min} znak"
+msgstr[1] "Co najmniej {Min} znaki"
+msgstr[2] "Co najmniej {Min} znaków"
+
+#, fuzzy
+msgid "INCORRECT_EMAIL"
+msgstr "Niepewne tłumaczenie"
+
+msgid "VALUE_NOT_FOUND"
+msgstr ""
+
+msgctxt "label"
+msgid "DELETE_LABEL"
+msgstr ""
+"Usuń \"to\""
+msgid "ORDER_LABEL"
+msgstr "Kolejność"
+
+#~ msgid "OLD"
+#~ msgstr "Stare"
+`
+
+func TestLoadPO(t *testing.T) {
+	fsys := fstest.MapFS{"locale/pl.po": {Data: []byte(testPO)}}
+
+	catalog, err := LoadPO(fsys, "locale/pl.po")
+	assert.Nil(t, err)
+	assert.Equal(t, catalog.Lang, "pl_PL")
+	assert.Equal(t, catalog.Messages, map[string]string{
+		"REQUIRED":     "To pole jest wymagane",
+		"DELETE_LABEL": `Usuń "to"`,
+		"ORDER_LABEL":  "Kolejność",
+	})
+	assert.Equal(t, catalog.Plurals, map[string][]string{
+		"INCORRECT_MIN_LENGTH": {"Co najmniej {Min} znak", "Co najmniej {Min} znaki", "Co najmniej {Min} znaków"},
+	})
+	assert.Equal(t, catalog.PluralRule(22), 1)
+
+	ve := NewValidationError("INCORRECT_MIN_LENGTH", "a", Params{"Min": 12})
+	assert.Equal(t, ve.Translate(catalog), "Co najmniej 12 znaków")
+}
+
+func TestLoadPOErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"string.po":  {Data: []byte("\"orphan\"\n")},
+		"keyword.po": {Data: []byte("msgfoo \"bar\"\n")},
+		"quote.po":   {Data: []byte("msgid \"bar\n")},
+		"index.po":   {Data: []byte("msgid \"a\"\nmsgstr[x] \"b\"\n")},
+		"plural.po":  {Data: []byte("msgid \"\"\nmsgstr \"Plural-Forms: nplurals=2; plural=n ?;\\n\"\n")},
+	}
+
+	for name := range fsys {
+		_, err := LoadPO(fsys, name)
+		assert.NotNil(t, err, "%s should be incorrect", name)
+	}
+
+	_, err := LoadPO(fsys, "missing.po")
+	assert.NotNil(t, err)
+}
+
+func TestLoadJSON(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locale/de.json":    {Data: []byte(`{"REQUIRED": "Pflichtfeld", "TOO_MANY_FILES": ["Max. {Max} Datei", "Max. {Max} Dateien"]}`)},
+		"locale/bad.json":   {Data: []byte(`{"REQUIRED": 1}`)},
+		"locale/forms.json": {Data: []byte(`{"REQUIRED": [1]}`)},
+		"locale/list.json":  {Data: []byte(`["REQUIRED"]`)},
+	}
+
+	catalog, err := LoadJSON(fsys, "locale/de.json")
+	assert.Nil(t, err)
+	assert.Equal(t, catalog.Lang, "de")
+	assert.Equal(t, catalog.Messages, map[string]string{"REQUIRED": "Pflichtfeld"})
+
+	msg, ok := catalog.TranslatePlural("TOO_MANY_FILES", 1)
+	assert.True(t, ok)
+	assert.Equal(t, msg, "Max. {Max} Datei")
+	msg, _ = catalog.TranslatePlural("TOO_MANY_FILES", 3)
+	assert.Equal(t, msg, "Max. {Max} Dateien")
+	msg, _ = catalog.Translate("TOO_MANY_FILES")
+	assert.Equal(t, msg, "Max. {Max} Dateien")
+
+	for _, name := range []string{"locale/bad.json", "locale/forms.json", "locale/list.json", "locale/missing.json"} {
+		_, err := LoadJSON(fsys, name)
+		assert.NotNil(t, err, "%s should be incorrect", name)
+	}
+}
+
+func TestLoadCatalogs(t *testing.T) {
+	defer func() {
+		catalogsMu.Lock()
+		delete(catalogs, "xx")
+		delete(catalogs, "yy")
+		catalogsMu.Unlock()
+	}()
+
+	fsys := fstest.MapFS{
+		"locale/xx.po":   {Data: []byte("msgid \"REQUIRED\"\nmsgstr \"XX\"\n")},
+		"locale/yy.json": {Data: []byte(`{"REQUIRED": "YY"}`)},
+		"locale/zz.txt":  {Data: []byte("REQUIRED=ZZ")},
+	}
+
+	assert.Nil(t, LoadCatalogs(fsys, "locale/*.*o*"))
+	catalog, ok := LookupCatalog("xx")
+	assert.True(t, ok)
+	assert.Equal(t, catalog.Messages["REQUIRED"], "XX")
+	catalog, ok = LookupCatalog("yy")
+	assert.True(t, ok)
+	assert.Equal(t, catalog.Messages["REQUIRED"], "YY")
+
+	assert.NotNil(t, LoadCatalogs(fsys, "locale/*"))
+	assert.NotNil(t, LoadCatalogs(fsys, "["))
+}
@@ -0,0 +1,91 @@
+package forms
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage returns language tags from Accept-Language header,
+// ordered by their quality (q-value). Tags with zero quality and "*" are
+// skipped.
+// Example
+//     forms.ParseAcceptLanguage("pl-PL, en;q=0.5, de;q=0.8")
+//     // []string{"pl-PL", "de", "en"}
+func ParseAcceptLanguage(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	languages := []language{}
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		tag := strings.TrimSpace(params[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			q, err := strconv.ParseFloat(param[len("q="):], 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			quality = q
+		}
+		if quality > 0 {
+			languages = append(languages, language{tag, quality})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	tags := make([]string, len(languages))
+	for i, language := range languages {
+		tags[i] = language.tag
+	}
+
+	return tags
+}
+
+// NegotiateCatalog returns registered catalog that matches Accept-Language
+// header best. Every language is matched with its whole tag and then with its
+// base language, ie. "pl-PL" and then "pl".
+func NegotiateCatalog(header string) (*Catalog, bool) {
+	for _, tag := range ParseAcceptLanguage(header) {
+		if catalog, ok := LookupCatalog(tag); ok {
+			return catalog, true
+		}
+		if i := strings.IndexAny(tag, "-_"); i > 0 {
+			if catalog, ok := LookupCatalog(tag[:i]); ok {
+				return catalog, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Localize sets form's translator to catalog negotiated from request's
+// Accept-Language header, when there is no matching catalog DefaultTranslator
+// is used. It should be called before form is validated or rendered.
+// Example
+//     form := newLoginForm()
+//     form.Localize(r)
+//     if form.IsValidRequest(r) {
+//         // ...
+//     }
+func (f *Form) Localize(r *http.Request) {
+	f.Translator = nil
+	if catalog, ok := NegotiateCatalog(r.Header.Get("Accept-Language")); ok {
+		f.Translator = catalog
+	}
+}
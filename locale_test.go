@@ -0,0 +1,67 @@
+package forms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	results := []struct {
+		header string
+		tags   []string
+	}{
+		{"", []string{}},
+		{"pl", []string{"pl"}},
+		{"pl-PL, en;q=0.5, de;q=0.8", []string{"pl-PL", "de", "en"}},
+		{"fr;q=0.7, en;q=0.7, pl", []string{"pl", "fr", "en"}},
+		{"*, de;q=0, en;q=0.1", []string{"en"}},
+		{"en;q=abc, fr ; q=0.3 ,, pl;level=1", []string{"pl", "fr"}},
+		{"en;q=2", []string{}},
+	}
+
+	for _, result := range results {
+		assert.Equal(t, ParseAcceptLanguage(result.header), result.tags, "Incorrect tags for %q", result.header)
+	}
+}
+
+func TestNegotiateCatalog(t *testing.T) {
+	results := []struct {
+		header  string
+		catalog *Catalog
+	}{
+		{"pl-PL,pl;q=0.9,en;q=0.8", Polish},
+		{"de-CH", German},
+		{"es, fr;q=0.5", French},
+		{"de;q=0.5, fr_FR", French},
+		{"en-US", English},
+	}
+
+	for _, result := range results {
+		catalog, ok := NegotiateCatalog(result.header)
+		assert.True(t, ok, "Catalog for %q should be found", result.header)
+		assert.Equal(t, catalog, result.catalog, "Incorrect catalog for %q", result.header)
+	}
+
+	_, ok := NegotiateCatalog("es, it")
+	assert.False(t, ok)
+}
+
+func TestFormLocalize(t *testing.T) {
+	f := New(map[string]*Field{"name": &Field{Validators: []Validator{&Required{}}}}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "pl-PL,pl;q=0.9")
+	f.Localize(r)
+	assert.Equal(t, f.Translator, Polish)
+	assert.False(t, f.IsValid(url.Values{}))
+	assert.Contains(t, f.Fields["name"].RenderErrors(), "To pole nie może być puste")
+
+	r.Header.Set("Accept-Language", "es")
+	f.Localize(r)
+	assert.Nil(t, f.Translator)
+	assert.Contains(t, f.Fields["name"].RenderErrors(), "This field can&#39;t be empty")
+}
@@ -0,0 +1,348 @@
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PluralRule returns index of plural form used for given count
+type PluralRule func(n int) int
+
+// PluralTranslator is translator that can choose form of message depending
+// on count. Count is taken from error's "Count", "Min" or "Max" parameter.
+type PluralTranslator interface {
+	Translator
+	TranslatePlural(key string, n int) (string, bool)
+}
+
+// englishPlural is rule of English and many other languages, with singular
+// for one and plural for other counts
+func englishPlural(n int) int {
+	if n == 1 {
+		return 0
+	}
+
+	return 1
+}
+
+// frenchPlural is rule of languages that use singular for zero and one
+func frenchPlural(n int) int {
+	if n > 1 {
+		return 1
+	}
+
+	return 0
+}
+
+// polishPlural is rule of Polish, with forms for one, counts ending with 2-4
+// (except 12-14) and others
+func polishPlural(n int) int {
+	switch {
+	case n == 1:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+		return 1
+	}
+
+	return 2
+}
+
+// slavicPlural is rule of Russian and Ukrainian
+func slavicPlural(n int) int {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+		return 1
+	}
+
+	return 2
+}
+
+// czechPlural is rule of Czech and Slovak
+func czechPlural(n int) int {
+	switch {
+	case n == 1:
+		return 0
+	case n >= 2 && n <= 4:
+		return 1
+	}
+
+	return 2
+}
+
+// singularPlural is rule of languages without plural forms
+func singularPlural(n int) int {
+	return 0
+}
+
+// pluralRules keeps plural rules of languages, language that is not listed
+// uses English rule
+var pluralRules = map[string]PluralRule{
+	"fr":    frenchPlural,
+	"pt-br": frenchPlural,
+	"pl":    polishPlural,
+	"ru":    slavicPlural,
+	"uk":    slavicPlural,
+	"cs":    czechPlural,
+	"sk":    czechPlural,
+	"ja":    singularPlural,
+	"ko":    singularPlural,
+	"zh":    singularPlural,
+}
+
+// pluralRuleFor returns plural rule of given language, base language is used
+// when there is no rule for regional variant
+func pluralRuleFor(lang string) PluralRule {
+	lang = normalizeLang(lang)
+	if rule, ok := pluralRules[lang]; ok {
+		return rule
+	}
+	if i := strings.Index(lang, "-"); i > 0 {
+		if rule, ok := pluralRules[lang[:i]]; ok {
+			return rule
+		}
+	}
+
+	return englishPlural
+}
+
+// pluralCount returns count used to choose plural form of error's message
+func pluralCount(params Params) (int, bool) {
+	for _, name := range []string{"Count", "Min", "Max"} {
+		switch v := params[name].(type) {
+		case int:
+			return v, true
+		case int64:
+			return int(v), true
+		case int32:
+			return int(v), true
+		case uint:
+			return int(v), true
+		case uint64:
+			return int(v), true
+		}
+	}
+
+	return 0, false
+}
+
+// ParsePluralRule parses plural expression used by gettext, ie.
+// "n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2". It
+// accepts also whole Plural-Forms header, ie. "nplurals=2; plural=(n != 1);"
+func ParsePluralRule(expr string) (PluralRule, error) {
+	if i := strings.Index(expr, "plural="); i >= 0 {
+		expr = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(expr[i+len("plural="):]), ";"))
+	}
+
+	p := &pluralParser{input: expr}
+	eval, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return PluralRule(eval), nil
+}
+
+// pluralExpr is compiled part of plural expression
+type pluralExpr func(n int) int
+
+// pluralParser is recursive descent parser of C-like plural expressions
+type pluralParser struct {
+	input string
+	pos   int
+}
+
+func (p *pluralParser) parse() (pluralExpr, error) {
+	expr, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("forms: unexpected %q in plural expression", p.input[p.pos:])
+	}
+
+	return expr, nil
+}
+
+func (p *pluralParser) skipSpaces() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips given operator if it's next in input
+func (p *pluralParser) consume(op string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], op) {
+		p.pos += len(op)
+		return true
+	}
+
+	return false
+}
+
+// binary parses left associative binary operators, next parses operands
+func (p *pluralParser) binary(next func() (pluralExpr, error), ops map[string]func(a, b int) int, order []string) (pluralExpr, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		matched := ""
+		for _, op := range order {
+			if p.consume(op) {
+				matched = op
+				break
+			}
+		}
+		if matched == "" {
+			return left, nil
+		}
+
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		l, r, fn := left, right, ops[matched]
+		left = func(n int) int {
+			return fn(l(n), r(n))
+		}
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func (p *pluralParser) ternary() (pluralExpr, error) {
+	cond, err := p.or()
+	if err != nil || !p.consume("?") {
+		return cond, err
+	}
+
+	yes, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(":") {
+		return nil, fmt.Errorf("forms: missing \":\" in plural expression")
+	}
+	no, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(n int) int {
+		if cond(n) != 0 {
+			return yes(n)
+		}
+		return no(n)
+	}, nil
+}
+
+func (p *pluralParser) or() (pluralExpr, error) {
+	return p.binary(p.and, map[string]func(a, b int) int{
+		"||": func(a, b int) int { return boolToInt(a != 0 || b != 0) },
+	}, []string{"||"})
+}
+
+func (p *pluralParser) and() (pluralExpr, error) {
+	return p.binary(p.equality, map[string]func(a, b int) int{
+		"&&": func(a, b int) int { return boolToInt(a != 0 && b != 0) },
+	}, []string{"&&"})
+}
+
+func (p *pluralParser) equality() (pluralExpr, error) {
+	return p.binary(p.relational, map[string]func(a, b int) int{
+		"==": func(a, b int) int { return boolToInt(a == b) },
+		"!=": func(a, b int) int { return boolToInt(a != b) },
+	}, []string{"==", "!="})
+}
+
+func (p *pluralParser) relational() (pluralExpr, error) {
+	return p.binary(p.additive, map[string]func(a, b int) int{
+		"<=": func(a, b int) int { return boolToInt(a <= b) },
+		">=": func(a, b int) int { return boolToInt(a >= b) },
+		"<":  func(a, b int) int { return boolToInt(a < b) },
+		">":  func(a, b int) int { return boolToInt(a > b) },
+	}, []string{"<=", ">=", "<", ">"})
+}
+
+func (p *pluralParser) additive() (pluralExpr, error) {
+	return p.binary(p.multiplicative, map[string]func(a, b int) int{
+		"+": func(a, b int) int { return a + b },
+		"-": func(a, b int) int { return a - b },
+	}, []string{"+", "-"})
+}
+
+func (p *pluralParser) multiplicative() (pluralExpr, error) {
+	return p.binary(p.unary, map[string]func(a, b int) int{
+		"*": func(a, b int) int { return a * b },
+		"/": func(a, b int) int {
+			if b == 0 {
+				return 0
+			}
+			return a / b
+		},
+		"%": func(a, b int) int {
+			if b == 0 {
+				return 0
+			}
+			return a % b
+		},
+	}, []string{"*", "/", "%"})
+}
+
+func (p *pluralParser) unary() (pluralExpr, error) {
+	if p.consume("!") {
+		expr, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int) int { return boolToInt(expr(n) == 0) }, nil
+	}
+
+	return p.primary()
+}
+
+func (p *pluralParser) primary() (pluralExpr, error) {
+	p.skipSpaces()
+	if p.consume("(") {
+		expr, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, fmt.Errorf("forms: missing \")\" in plural expression")
+		}
+		return expr, nil
+	}
+	if p.consume("n") {
+		return func(n int) int { return n }, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("forms: unexpected end of plural expression")
+		}
+		return nil, fmt.Errorf("forms: unexpected %q in plural expression", p.input[p.pos:])
+	}
+	value, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil {
+		return nil, err
+	}
+
+	return func(n int) int { return value }, nil
+}
@@ -0,0 +1,75 @@
+package forms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluralRules(t *testing.T) {
+	counts := []int{0, 1, 2, 4, 5, 11, 12, 21, 22, 25, 101, 112, 122}
+	results := []struct {
+		lang  string
+		forms []int
+	}{
+		{"en", []int{1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+		{"fr", []int{0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+		{"pl", []int{2, 0, 1, 1, 2, 2, 2, 2, 1, 2, 2, 2, 1}},
+		{"pl-PL", []int{2, 0, 1, 1, 2, 2, 2, 2, 1, 2, 2, 2, 1}},
+		{"ru", []int{2, 0, 1, 1, 2, 2, 2, 0, 1, 2, 0, 2, 1}},
+		{"cs", []int{2, 0, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2}},
+		{"ja", []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, result := range results {
+		rule := pluralRuleFor(result.lang)
+		for i, n := range counts {
+			assert.Equal(t, rule(n), result.forms[i], "Incorrect form for %d in %s", n, result.lang)
+		}
+	}
+}
+
+func TestParsePluralRule(t *testing.T) {
+	rule, err := ParsePluralRule("nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);")
+	assert.Nil(t, err)
+	for _, n := range []int{0, 1, 2, 4, 5, 12, 22, 25, 112, 122} {
+		assert.Equal(t, rule(n), polishPlural(n), "Incorrect form for %d", n)
+	}
+
+	rule, err = ParsePluralRule("n != 1")
+	assert.Nil(t, err)
+	assert.Equal(t, rule(1), 0)
+	assert.Equal(t, rule(2), 1)
+
+	rule, err = ParsePluralRule("!(n > 1) ? 0 : n / 0 + n * 2 - 1")
+	assert.Nil(t, err)
+	assert.Equal(t, rule(1), 0)
+	assert.Equal(t, rule(3), 5)
+
+	for _, expr := range []string{"", "n ==", "(n", "n ? 1", "n = 1", "x"} {
+		_, err := ParsePluralRule(expr)
+		assert.NotNil(t, err, "Expression %q should be incorrect", expr)
+	}
+}
+
+func TestValidationErrorPlural(t *testing.T) {
+	results := []struct {
+		min     int
+		message string
+	}{
+		{1, `Wartość "a" musi mieć co najmniej 1 znak`},
+		{3, `Wartość "a" musi mieć co najmniej 3 znaki`},
+		{5, `Wartość "a" musi mieć co najmniej 5 znaków`},
+		{22, `Wartość "a" musi mieć co najmniej 22 znaki`},
+	}
+	for _, result := range results {
+		err := NewValidationError("INCORRECT_MIN_LENGTH", "a", Params{"Min": result.min})
+		assert.Equal(t, err.Translate(Polish), result.message)
+	}
+
+	err := NewValidationError("FILE_TOO_BIG", "a.png", Params{"Max": int64(2)})
+	assert.Equal(t, err.Translate(Polish), `Plik "a.png" jest za duży, może mieć co najwyżej 2 bajty`)
+
+	err = NewValidationError("REQUIRED", "", Params{"Count": 2})
+	assert.Equal(t, err.Translate(Polish), "To pole nie może być puste", "Messages without plural forms should be used")
+}
@@ -0,0 +1,113 @@
+package forms
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// ProblemContentType is content type of responses written by WriteProblem
+const ProblemContentType = "application/problem+json"
+
+// InvalidParam describes single error in Problem, name is empty for form
+// errors
+type InvalidParam struct {
+	Name   string `json:"name,omitempty"`
+	Code   string `json:"code,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Problem is body of "application/problem+json" response (RFC 7807) which
+// describes why form is invalid. Errors of fields are put into
+// "invalid-params" extension, errors of whole form into "errors" extension.
+type Problem struct {
+	Type          string         `json:"type,omitempty"`
+	Title         string         `json:"title,omitempty"`
+	Status        int            `json:"status,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+	Errors        []InvalidParam `json:"errors,omitempty"`
+}
+
+// Error returns title of problem, followed by detail if it's set
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+
+	return p.Title
+}
+
+// ValidationErrors returns errors described by problem, form errors come first
+func (p *Problem) ValidationErrors() ValidationErrors {
+	errs := ValidationErrors{}
+	for _, param := range p.Errors {
+		errs = append(errs, &ValidationError{Code: param.Code, Message: param.Reason})
+	}
+	for _, param := range p.InvalidParams {
+		errs = append(errs, &ValidationError{Code: param.Code, Field: param.Name, Message: param.Reason})
+	}
+
+	return errs
+}
+
+// Problem returns problem describing errors of form, names of fields include
+// form's prefix. When status is zero 422 (Unprocessable Entity) is used.
+func (f *Form) Problem(status int) *Problem {
+	if status == 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	problem := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	t := f.translator()
+	for _, err := range f.Errors {
+		problem.Errors = append(problem.Errors, InvalidParam{Code: err.Code, Reason: err.Translate(t)})
+	}
+	for _, field := range f.FieldList() {
+		for _, err := range field.Errors {
+			problem.InvalidParams = append(problem.InvalidParams, InvalidParam{
+				Name:   field.HTMLName(),
+				Code:   err.Code,
+				Reason: err.Translate(t),
+			})
+		}
+	}
+
+	return problem
+}
+
+// WriteProblem writes "application/problem+json" response with errors of form
+// Example
+//     if !form.IsValidRequest(r) {
+//         form.WriteProblem(w, http.StatusBadRequest)
+//         return
+//     }
+func (f *Form) WriteProblem(w http.ResponseWriter, status int) error {
+	problem := f.Problem(status)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+
+	return json.NewEncoder(w).Encode(problem)
+}
+
+// DecodeProblem reads problem from response body written by WriteProblem
+// Example
+//     problem, err := forms.DecodeProblem(resp.Body)
+//     if err == nil {
+//         for _, param := range problem.InvalidParams {
+//             fmt.Println(param.Name, param.Code, param.Reason)
+//         }
+//     }
+func DecodeProblem(r io.Reader) (*Problem, error) {
+	problem := &Problem{}
+	if err := json.NewDecoder(r).Decode(problem); err != nil {
+		return nil, err
+	}
+
+	return problem, nil
+}
@@ -0,0 +1,75 @@
+package forms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormProblem(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "name", Validators: []Validator{&Required{}}},
+		{Name: "email", Validators: []Validator{&Email{}}},
+	}, nil)
+	f.Prefix = "user"
+
+	assert.False(t, f.IsValid(url.Values{"user-email": {"foo"}}))
+	f.AddError("Form error")
+
+	assert.Equal(t, f.Problem(0), &Problem{
+		Type:   "about:blank",
+		Title:  "Unprocessable Entity",
+		Status: http.StatusUnprocessableEntity,
+		InvalidParams: []InvalidParam{
+			{Name: "user-name", Code: "REQUIRED", Reason: translations["REQUIRED"]},
+			{Name: "user-email", Code: "INCORRECT_EMAIL", Reason: `"foo" is not correct email address`},
+		},
+		Errors: []InvalidParam{{Reason: "Form error"}},
+	})
+	assert.Equal(t, f.Problem(http.StatusBadRequest).Title, "Bad Request")
+}
+
+func TestFormWriteProblem(t *testing.T) {
+	f := New(map[string]*Field{"name": &Field{Validators: []Validator{&Required{}}}}, nil)
+	assert.False(t, f.IsValid(url.Values{}))
+
+	w := httptest.NewRecorder()
+	assert.Nil(t, f.WriteProblem(w, 0))
+	assert.Equal(t, w.Code, http.StatusUnprocessableEntity)
+	assert.Equal(t, w.Header().Get("Content-Type"), ProblemContentType)
+	assert.JSONEq(t, w.Body.String(), `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"invalid-params": [{"name": "name", "code": "REQUIRED", "reason": "This field can't be empty"}]
+	}`)
+
+	problem, err := DecodeProblem(w.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, problem, f.Problem(0))
+	assert.Equal(t, problem.Error(), "Unprocessable Entity")
+	assert.Equal(t, problem.ValidationErrors(), ValidationErrors{
+		{Code: "REQUIRED", Field: "name", Message: translations["REQUIRED"]},
+	})
+}
+
+func TestDecodeProblem(t *testing.T) {
+	problem, err := DecodeProblem(strings.NewReader(`{
+		"title": "Bad Request",
+		"detail": "Request is invalid",
+		"errors": [{"code": "REQUEST_INVALID", "reason": "Sent data couldn't be read"}]
+	}`))
+	assert.Nil(t, err)
+	assert.Equal(t, problem.Error(), "Bad Request: Request is invalid")
+	assert.Equal(t, problem.ValidationErrors(), ValidationErrors{
+		{Code: "REQUEST_INVALID", Message: "Sent data couldn't be read"},
+	})
+
+	problem, err = DecodeProblem(strings.NewReader("{"))
+	assert.NotNil(t, err)
+	assert.Nil(t, problem)
+}
@@ -0,0 +1,113 @@
+package forms
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultMaxBodySize is limit of request body size used by IsValidRequest
+const DefaultMaxBodySize = 10 << 20
+
+// DefaultMaxMemory is number of bytes of multipart form that are kept in
+// memory, rest of files are stored in temporary files
+const DefaultMaxMemory = 32 << 20
+
+// errBodyTooLarge is returned when request body exceeds limit
+var errBodyTooLarge = errors.New("forms: request body too large")
+
+// limitedBody is request body that returns errBodyTooLarge when more than
+// limit bytes are read
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+// Read reads from underlying body, one byte over the limit is read to detect
+// if body is too large
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n - 1, errBodyTooLarge
+	}
+
+	return n, err
+}
+
+// maxBodySize returns limit of request body size
+func (f *Form) maxBodySize() int64 {
+	if f.MaxBodySize <= 0 {
+		return DefaultMaxBodySize
+	}
+
+	return f.MaxBodySize
+}
+
+// requestError clears form and adds error describing why request couldn't be
+// parsed
+func (f *Form) requestError(err error) bool {
+	f.Clear()
+	if errors.Is(err, errBodyTooLarge) {
+		f.AddValidationError(NewValidationError("REQUEST_TOO_LARGE", "", nil))
+	} else {
+		f.AddValidationError(NewValidationError("REQUEST_INVALID", "", nil))
+	}
+
+	return false
+}
+
+// IsValidRequest validates data from request. For GET, HEAD, DELETE and
+// OPTIONS requests data is taken from query string, for other methods it's
+// taken from body, depending on its content type: urlencoded form, multipart
+// form or JSON object. Size of body is limited by form's MaxBodySize.
+//
+// When request can't be parsed, form gets an error and validation fails.
+// Request's context is passed to validators, like in IsValidContext.
+func (f *Form) IsValidRequest(r *http.Request) bool {
+	ctx := r.Context()
+	switch r.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return f.isValid(ctx, submission{values: r.URL.Query()})
+	}
+
+	if r.Body == nil {
+		return f.requestError(errors.New("forms: request has no body"))
+	}
+	r.Body = &limitedBody{ReadCloser: r.Body, remaining: f.maxBodySize()}
+
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil && contentType != "" {
+		return f.requestError(err)
+	}
+
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return f.requestError(err)
+		}
+		return f.isValid(ctx, submission{values: r.PostForm})
+	case mediaType == "multipart/form-data":
+		if err := r.ParseMultipartForm(DefaultMaxMemory); err != nil {
+			return f.requestError(err)
+		}
+		return f.isValid(ctx, submission{values: url.Values(r.MultipartForm.Value), files: r.MultipartForm.File})
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return f.isValidJSON(ctx, r.Body)
+	}
+
+	f.Clear()
+	f.AddValidationError(NewValidationError("UNSUPPORTED_CONTENT_TYPE", mediaType, nil))
+	return false
+}
@@ -0,0 +1,131 @@
+package forms
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRequestForm() *Form {
+	return New(map[string]*Field{
+		"name": &Field{Validators: []Validator{&Required{}}},
+		"file": &Field{Type: &File{}},
+	}, nil)
+}
+
+func TestFormIsValidRequestQuery(t *testing.T) {
+	f := newRequestForm()
+
+	r := httptest.NewRequest(http.MethodGet, "/?name=Foo", nil)
+	assert.True(t, f.IsValidRequest(r))
+	assert.Equal(t, f.CleanedData["name"], "Foo")
+
+	r = httptest.NewRequest(http.MethodPost, "/?name=Foo", strings.NewReader("other=1"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.False(t, f.IsValidRequest(r), "Query string shouldn't be used for POST")
+}
+
+func TestFormIsValidRequestURLEncoded(t *testing.T) {
+	f := newRequestForm()
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Foo"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	assert.True(t, f.IsValidRequest(r))
+	assert.Equal(t, f.CleanedData["name"], "Foo")
+
+	r = httptest.NewRequest(http.MethodPut, "/", strings.NewReader("name=%zz"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorCodes(f.Errors), []string{"REQUEST_INVALID"})
+}
+
+func TestFormIsValidRequestMultipart(t *testing.T) {
+	f := newRequestForm()
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	assert.NoError(t, w.WriteField("name", "Foo"))
+	part, _ := w.CreateFormFile("file", "a.txt")
+	_, _ = part.Write([]byte("content"))
+	assert.NoError(t, w.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	assert.True(t, f.IsValidRequest(r))
+	assert.Equal(t, f.CleanedData["name"], "Foo")
+	assert.Equal(t, f.CleanedData["file"].(*multipart.FileHeader).Filename, "a.txt")
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("garbage"))
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=xxx")
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorCodes(f.Errors), []string{"REQUEST_INVALID"})
+}
+
+func TestFormIsValidRequestJSON(t *testing.T) {
+	f := newRequestForm()
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "Foo"}`))
+	r.Header.Set("Content-Type", "application/json")
+	assert.True(t, f.IsValidRequest(r))
+	assert.Equal(t, f.CleanedData["name"], "Foo")
+
+	r = httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"name": `))
+	r.Header.Set("Content-Type", "application/merge-patch+json")
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorCodes(f.Errors), []string{"REQUEST_INVALID"})
+}
+
+func TestFormIsValidRequestErrors(t *testing.T) {
+	f := newRequestForm()
+	f.MaxBodySize = 10
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=FooBarBaz"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorCodes(f.Errors), []string{"REQUEST_TOO_LARGE"})
+	assert.Nil(t, f.CleanedData)
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "FooBarBaz"}`))
+	r.Header.Set("Content-Type", "application/json")
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorCodes(f.Errors), []string{"REQUEST_TOO_LARGE"})
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Foo"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.True(t, f.IsValidRequest(r), "Body within limit should pass")
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Foo"))
+	r.Header.Set("Content-Type", "text/plain")
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorMessages(f.Errors), []string{`Content type "text/plain" is not supported`})
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Foo"))
+	r.Header.Set("Content-Type", "text/plain; ===")
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorCodes(f.Errors), []string{"REQUEST_INVALID"})
+
+	r = httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Body = nil
+	assert.False(t, f.IsValidRequest(r))
+	assert.Equal(t, errorCodes(f.Errors), []string{"REQUEST_INVALID"})
+}
+
+func TestLimitedBody(t *testing.T) {
+	body := &limitedBody{ReadCloser: http.NoBody, remaining: 0}
+	n, err := body.Read(make([]byte, 10))
+	assert.Equal(t, n, 0)
+	assert.Equal(t, err, io.EOF, "Empty body fits in zero limit")
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("12345"))
+	body = &limitedBody{ReadCloser: r.Body, remaining: 5}
+	buf := make([]byte, 10)
+	n, err = body.Read(buf)
+	assert.Equal(t, n, 5)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,136 @@
+package forms
+
+// JSONSchemaDialect is URI of JSON Schema version used by JSONSchema
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonFormat returns JSON Schema format of values of given type. InputTime
+// has no format, as "time" format requires seconds and time zone, which
+// aren't sent by browsers.
+func jsonFormat(t Type) string {
+	switch t.(type) {
+	case *InputEmail:
+		return "email"
+	case *InputDate:
+		return "date"
+	case *InputURL:
+		return "uri"
+	}
+
+	return ""
+}
+
+// JSONSchema returns JSON Schema (draft 2020-12) describing object accepted by
+// IsValidJSON, result can be encoded with encoding/json. Fields with file types
+// are skipped, as files can't be sent in JSON.
+//
+// Type of field is mapped to JSON type and format, choices (unless field has
+// FreeEntry) are described by "oneOf" and validators are mapped to keywords:
+// Required to "required", MinLength to "minLength", MaxLength to "maxLength",
+// Regexp to "pattern", InSlice to "enum", Email to "email" format and
+// MinSelected, MaxSelected to "minItems", "maxItems". Fields with conditions
+// are never listed in "required".
+func (f *Form) JSONSchema() map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	for _, field := range f.FieldList() {
+		if field.Type == nil {
+			field.Type = &Input{}
+		}
+		if _, isFile := field.Type.(FileType); isFile {
+			continue
+		}
+
+		property, isRequired := fieldSchema(field)
+		properties[field.HTMLName()] = property
+		if isRequired && len(field.Conditions) == 0 {
+			required = append(required, field.HTMLName())
+		}
+	}
+
+	schema := map[string]interface{}{
+		"$schema":    JSONSchemaDialect,
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+// fieldSchema returns schema of field's value and information if field is
+// required
+func fieldSchema(field *Field) (map[string]interface{}, bool) {
+	value := map[string]interface{}{
+		"type": jsonKind(field.Type),
+	}
+	if format := jsonFormat(field.Type); format != "" {
+		value["format"] = format
+	}
+	if len(field.Choices) > 0 && !field.FreeEntry {
+		choices := []interface{}{}
+		for _, choice := range flattenChoices(field.Choices) {
+			if !choice.Disabled {
+				choices = append(choices, map[string]interface{}{"const": choice.Value, "title": choice.Label})
+			}
+		}
+		value["oneOf"] = choices
+	}
+
+	isRequired := false
+	minItems, maxItems := 0, -1
+	for _, validator := range field.Validators {
+		switch v := validator.(type) {
+		case *Required:
+			isRequired = true
+		case *MinSelected:
+			minItems = v.Min
+		case *MaxSelected:
+			maxItems = v.Max
+		case *MinLength:
+			value["minLength"] = v.Min
+		case *MaxLength:
+			value["maxLength"] = v.Max
+		case *Regexp:
+			value["pattern"] = v.Pattern
+		case *InSlice:
+			value["enum"] = v.Values
+		case *Email:
+			value["format"] = "email"
+		}
+	}
+
+	// Required rejects empty strings and unchecked checkboxes
+	if _, ok := value["minLength"]; isRequired && !ok && value["type"] == "string" {
+		value["minLength"] = 1
+	}
+	if _, ok := field.Type.(*Checkbox); ok && isRequired {
+		value["const"] = true
+	}
+
+	schema := value
+	if field.Type.IsMultiValue() {
+		schema = map[string]interface{}{
+			"type":  "array",
+			"items": value,
+		}
+		if isRequired && minItems < 1 {
+			minItems = 1
+		}
+		if minItems > 0 {
+			schema["minItems"] = minItems
+		}
+		if maxItems >= 0 {
+			schema["maxItems"] = maxItems
+		}
+	}
+	if field.Label != "" {
+		schema["title"] = field.label()
+	}
+	if field.HelpText != "" {
+		schema["description"] = field.HelpText
+	}
+
+	return schema, isRequired
+}
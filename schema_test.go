@@ -0,0 +1,99 @@
+package forms
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormJSONSchema(t *testing.T) {
+	f := NewOrdered([]*Field{
+		{Name: "email", Label: "E-mail", HelpText: "Your e-mail", Type: &InputEmail{}, Validators: []Validator{&Required{}, &MaxLength{64}}},
+		{Name: "nick", Validators: []Validator{&MinLength{3}, &Regexp{"^[a-z]+$"}, &Email{}}},
+		{Name: "born", Type: &InputDate{}},
+		{Name: "at", Type: &InputTime{}},
+		{Name: "age", Type: &InputNumber{}},
+		{Name: "accept", Type: &Checkbox{}, Validators: []Validator{&Required{}}},
+		{Name: "size", Validators: []Validator{&InSlice{[]string{"s", "m"}}}},
+		{Name: "food", Type: &Radio{}, Validators: []Validator{&Required{}}, Choices: []Choice{
+			{Value: "pizza", Label: "Pizza"},
+			{Value: "pasta", Label: "Pasta"},
+		}},
+		{Name: "avatar", Type: &File{}},
+	}, nil)
+	f.Prefix = "p"
+
+	schema := f.JSONSchema()
+	assert.Equal(t, schema, map[string]interface{}{
+		"$schema": JSONSchemaDialect,
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"p-email": map[string]interface{}{
+				"type": "string", "format": "email", "title": "E-mail", "description": "Your e-mail",
+				"maxLength": 64, "minLength": 1,
+			},
+			"p-nick": map[string]interface{}{
+				"type": "string", "format": "email", "minLength": 3, "pattern": "^[a-z]+$",
+			},
+			"p-born":   map[string]interface{}{"type": "string", "format": "date"},
+			"p-at":     map[string]interface{}{"type": "string"},
+			"p-age":    map[string]interface{}{"type": "number"},
+			"p-accept": map[string]interface{}{"type": "boolean", "const": true},
+			"p-size":   map[string]interface{}{"type": "string", "enum": []string{"s", "m"}},
+			"p-food": map[string]interface{}{
+				"type":      "string",
+				"minLength": 1,
+				"oneOf": []interface{}{
+					map[string]interface{}{"const": "pizza", "title": "Pizza"},
+					map[string]interface{}{"const": "pasta", "title": "Pasta"},
+				},
+			},
+		},
+		"required": []string{"p-email", "p-accept", "p-food"},
+	})
+
+	_, err := json.Marshal(schema)
+	assert.NoError(t, err)
+
+	f = New(map[string]*Field{"toppings": {Type: &CheckboxMultiple{}, Validators: []Validator{
+		&Required{}, &MinSelected{2}, &MaxSelected{3},
+	}}}, nil)
+	assert.Equal(t, f.JSONSchema()["properties"], map[string]interface{}{
+		"toppings": map[string]interface{}{
+			"type":     "array",
+			"items":    map[string]interface{}{"type": "string", "minLength": 1},
+			"minItems": 2,
+			"maxItems": 3,
+		},
+	})
+
+	f = New(map[string]*Field{"food": {Choices: []Choice{{Value: "sushi", Label: "Sushi"}}, FreeEntry: true}}, nil)
+	assert.Equal(t, f.JSONSchema()["properties"], map[string]interface{}{
+		"food": map[string]interface{}{"type": "string"},
+	}, "Choices of field with free entry shouldn't be listed")
+
+	f = New(map[string]*Field{"food": {Type: &SelectMultiple{}, Choices: []Choice{
+		{Value: "sushi", Label: "Sushi"},
+		{Value: "burger", Label: "Burger", Disabled: true},
+		{Label: "Italian", Choices: []Choice{{Value: "pizza", Label: "Pizza"}}},
+	}}}, nil)
+	assert.Equal(t, f.JSONSchema()["properties"], map[string]interface{}{
+		"food": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "string",
+				"oneOf": []interface{}{
+					map[string]interface{}{"const": "sushi", "title": "Sushi"},
+					map[string]interface{}{"const": "pizza", "title": "Pizza"},
+				},
+			},
+		},
+	}, "Groups of choices should be flattened")
+
+	assert.Equal(t, New(nil, nil).JSONSchema(), map[string]interface{}{
+		"$schema":    JSONSchemaDialect,
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	})
+}
@@ -0,0 +1,238 @@
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// typesByName maps type names used in "form" struct tag to field types
+var typesByName = map[string]func() Type{
+	"input":       func() Type { return &Input{} },
+	"text":        func() Type { return &Input{} },
+	"textarea":    func() Type { return &Textarea{} },
+	"number":      func() Type { return &InputNumber{} },
+	"checkbox":    func() Type { return &Checkbox{} },
+	"radio":       func() Type { return &Radio{} },
+	"select":      func() Type { return &Select{} },
+	"multiselect": func() Type { return &SelectMultiple{} },
+	"checkboxes":  func() Type { return &CheckboxMultiple{} },
+	"inputs":      func() Type { return &InputMultiple{} },
+	"email":       func() Type { return &InputEmail{} },
+	"password":    func() Type { return &InputPassword{} },
+	"date":        func() Type { return &InputDate{} },
+	"time":        func() Type { return &InputTime{} },
+	"datetime":    func() Type { return &InputDateTime{} },
+	"month":       func() Type { return &InputMonth{} },
+	"week":        func() Type { return &InputWeek{} },
+	"url":         func() Type { return &InputURL{} },
+	"tel":         func() Type { return &InputTel{} },
+	"search":      func() Type { return &InputSearch{} },
+	"hidden":      func() Type { return &InputHidden{} },
+	"file":        func() Type { return &File{} },
+	"files":       func() Type { return &MultipleFile{} },
+}
+
+// FromStruct creates form basing on struct (or pointer to struct) fields,
+// fields are kept in order of declaration and current values of struct are
+// used as initial data.
+//
+// Field is configured by "form" tag, which contains field name followed by
+// options: "type", "label" and "help". Type is one of: input, text, textarea,
+// number, checkbox, radio, select, multiselect, checkboxes, inputs, email,
+// password, date, time, datetime, month, week, url, tel, search, hidden, file
+// or files. Choices of choice types need to be set on created form. When type
+// isn't given it's guessed from struct field type, slices get InputMultiple, so
+// form accepts all their values.
+//
+// Validators are configured by "validate" tag, known validators are:
+// "required", "email", "min" and "max" (length of value), "in" (values
+// separated by "|") and "regexp", which need to be last as pattern takes rest
+// of the tag.
+// Example
+//     type Register struct {
+//         Email string `form:"email,type=email,label=E-mail" validate:"required,max=64"`
+//         Age   int    `form:"age,label=Age"`
+//     }
+//     form, err := forms.FromStruct(Register{})
+func FromStruct(v interface{}) (*Form, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("forms: FromStruct expects struct, got %T", v)
+	}
+
+	form := NewOrdered(nil, nil)
+	initial := Data{}
+	for _, sf := range structFields(rv.Type()) {
+		field, err := structToField(sf)
+		if err != nil {
+			return nil, err
+		}
+		form.AddField(field)
+
+		if value, ok := initialValue(rv.FieldByIndex(sf.index), field.Type); ok {
+			initial[field.Name] = value
+		}
+	}
+	form.SetInitial(initial)
+
+	return form, nil
+}
+
+// structToField creates field using tags of given struct field
+func structToField(sf structField) (*Field, error) {
+	field := &Field{
+		Name:     sf.name,
+		Label:    sf.field.Name,
+		HelpText: sf.options["help"],
+	}
+	if label, ok := sf.options["label"]; ok {
+		field.Label = label
+	}
+
+	if typeName, ok := sf.options["type"]; ok {
+		newType, ok := typesByName[typeName]
+		if !ok {
+			return nil, fmt.Errorf("forms: unknown type %q for field %q", typeName, sf.name)
+		}
+		field.Type = newType()
+	} else {
+		field.Type = guessType(sf.field.Type)
+	}
+
+	validators, err := parseValidators(sf.field.Tag.Get("validate"))
+	if err != nil {
+		return nil, fmt.Errorf("forms: field %q: %v", sf.name, err)
+	}
+	field.Validators = validators
+
+	return field, nil
+}
+
+// guessType returns field type that fits given Go type
+func guessType(t reflect.Type) Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &InputDate{}
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return &InputMultiple{}
+	case reflect.Bool:
+		return &Checkbox{}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return &InputNumber{}
+	}
+
+	return &Input{}
+}
+
+// parseValidators creates validators from "validate" tag
+func parseValidators(tag string) ([]Validator, error) {
+	var validators []Validator
+	if tag == "" {
+		return validators, nil
+	}
+
+	pattern := ""
+	if i := strings.Index(tag, "regexp="); i >= 0 {
+		pattern = tag[i+len("regexp="):]
+		tag = tag[:i]
+	}
+
+	_, options := parseTag("," + tag)
+	for _, name := range strings.Split(tag, ",") {
+		name = strings.TrimSpace(strings.SplitN(name, "=", 2)[0])
+		if name == "" {
+			continue
+		}
+
+		value := options[name]
+		switch name {
+		case "required":
+			validators = append(validators, &Required{})
+		case "email":
+			validators = append(validators, &Email{})
+		case "min", "max":
+			length, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("incorrect value %q for %q validator", value, name)
+			}
+			if name == "min" {
+				validators = append(validators, &MinLength{Min: length})
+			} else {
+				validators = append(validators, &MaxLength{Max: length})
+			}
+		case "in":
+			validators = append(validators, &InSlice{Values: strings.Split(value, "|")})
+		default:
+			return nil, fmt.Errorf("unknown validator %q", name)
+		}
+	}
+
+	if pattern != "" {
+		validators = append(validators, &Regexp{Pattern: pattern})
+	}
+
+	return validators, nil
+}
+
+// initialValue converts struct field value to value that can be used as
+// field's initial value, nil pointers, zero time and false are skipped, as
+// checkbox is checked by any non empty value
+func initialValue(v reflect.Value, t Type) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Bool && !v.Bool() {
+		return nil, false
+	}
+
+	if v.Type() == timeType {
+		tm := v.Interface().(time.Time)
+		if tm.IsZero() {
+			return nil, false
+		}
+		return tm.Format(timeLayout(t)), true
+	}
+
+	if v.Kind() == reflect.Slice {
+		values := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values[i] = v.Index(i).Interface()
+		}
+		return values, true
+	}
+
+	return v.Interface(), true
+}
+
+// timeLayout returns layout in which time should be rendered for given type
+func timeLayout(t Type) string {
+	switch t.(type) {
+	case *InputDateTime:
+		return "2006-01-02T15:04"
+	case *InputTime:
+		return "15:04"
+	case *InputMonth:
+		return "2006-01"
+	case *InputDate:
+		return "2006-01-02"
+	}
+
+	return time.RFC3339
+}
@@ -0,0 +1,151 @@
+package forms
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type registerStruct struct {
+	Email    string    `form:"email,type=email,label=E-mail,help=We won't spam" validate:"required,max=64,email"`
+	Password string    `form:"password,type=password" validate:"required,min=8"`
+	Code     string    `form:"code" validate:"regexp=^[a-z]{2,3}$"`
+	Food     string    `form:"food,type=radio" validate:"in=pizza|pasta"`
+	Age      int       `form:"age"`
+	Born     time.Time `form:"born"`
+	Accept   bool      `form:"accept"`
+	Tags     []string  `form:"tags"`
+	Nick     *string   `form:"nick"`
+	Bio      string    `form:"-"`
+}
+
+func TestFromStruct(t *testing.T) {
+	f, err := FromStruct(registerStruct{})
+	assert.NoError(t, err)
+	assert.Equal(t, fieldListNames(f), []string{
+		"email", "password", "code", "food", "age", "born", "accept", "tags", "nick",
+	})
+
+	email := f.Fields["email"]
+	assert.Equal(t, email.Type, &InputEmail{})
+	assert.Equal(t, email.Label, "E-mail")
+	assert.Equal(t, email.HelpText, "We won't spam")
+	assert.Equal(t, email.Validators, []Validator{&Required{}, &MaxLength{Max: 64}, &Email{}})
+
+	assert.Equal(t, f.Fields["password"].Type, &InputPassword{})
+	assert.Equal(t, f.Fields["password"].Label, "Password")
+	assert.Equal(t, f.Fields["password"].Validators, []Validator{&Required{}, &MinLength{Min: 8}})
+	assert.Equal(t, f.Fields["code"].Validators, []Validator{&Regexp{Pattern: "^[a-z]{2,3}$"}})
+	assert.Equal(t, f.Fields["food"].Type, &Radio{})
+	assert.Equal(t, f.Fields["food"].Validators, []Validator{&InSlice{Values: []string{"pizza", "pasta"}}})
+	assert.Equal(t, f.Fields["age"].Type, &InputNumber{})
+	assert.Equal(t, f.Fields["born"].Type, &InputDate{})
+	assert.Equal(t, f.Fields["accept"].Type, &Checkbox{})
+	assert.Equal(t, f.Fields["tags"].Type, &InputMultiple{})
+	assert.Equal(t, f.Fields["nick"].Type, &Input{})
+}
+
+func TestFromStructInitial(t *testing.T) {
+	nick := "johnny"
+	f, err := FromStruct(&registerStruct{
+		Email: "john@example.com",
+		Age:   30,
+		Born:  time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC),
+		Tags:  []string{"a", "b"},
+		Nick:  &nick,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, f.Fields["email"].InitialValue, "john@example.com")
+	assert.Equal(t, f.Fields["age"].InitialValue, 30)
+	assert.Equal(t, f.Fields["born"].InitialValue, "2001-02-03")
+	assert.Equal(t, f.Fields["tags"].InitialValue, []interface{}{"a", "b"})
+	assert.Equal(t, f.Fields["nick"].InitialValue, "johnny")
+	assert.Nil(t, f.Fields["accept"].InitialValue)
+	assert.Contains(t, f.Fields["born"].Render(), ` value="2001-02-03"`)
+	assert.NotContains(t, f.Fields["accept"].Render(), `checked`)
+}
+
+func TestFromStructRoundTrip(t *testing.T) {
+	nick := "johnny"
+	src := registerStruct{
+		Email:    "john@example.com",
+		Password: "long enough",
+		Food:     "pizza",
+		Age:      30,
+		Born:     time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC),
+		Accept:   true,
+		Tags:     []string{"a", "b"},
+		Nick:     &nick,
+	}
+	f, err := FromStruct(src)
+	assert.NoError(t, err)
+
+	tags := f.Fields["tags"].Render()
+	assert.Contains(t, tags, `<input name="tags" type="input" id="f_tags_0" value="a" />`)
+	assert.Contains(t, tags, `<input name="tags" type="input" id="f_tags_1" value="b" />`)
+
+	assert.True(t, f.IsValid(url.Values{
+		"email": {"john@example.com"}, "password": {"long enough"}, "food": {"pizza"}, "age": {"30"},
+		"born": {"2001-02-03"}, "accept": {"on"}, "tags": {"a", "b"}, "nick": {"johnny"},
+	}), "Form should accept its own initial values")
+
+	var dst registerStruct
+	assert.NoError(t, f.Bind(&dst))
+	assert.Equal(t, dst, src)
+}
+
+func TestFromStructValidation(t *testing.T) {
+	f, err := FromStruct(registerStruct{})
+	assert.NoError(t, err)
+
+	assert.False(t, f.IsValid(url.Values{"email": {"john@example.com"}, "password": {"short"}}))
+	assert.True(t, f.Fields["password"].HasErrors())
+
+	assert.True(t, f.IsValid(url.Values{
+		"email": {"john@example.com"}, "password": {"long enough"}, "age": {"30"}, "born": {"2001-02-03"},
+	}))
+
+	var dst registerStruct
+	assert.NoError(t, f.Bind(&dst))
+	assert.Equal(t, dst.Email, "john@example.com")
+	assert.Equal(t, dst.Age, 30)
+	assert.Equal(t, dst.Born, time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC))
+}
+
+func TestFromStructChoiceTypes(t *testing.T) {
+	f, err := FromStruct(struct {
+		Size     string   `form:"size,type=select"`
+		Foods    []string `form:"foods,type=multiselect"`
+		Toppings []string `form:"toppings,type=checkboxes"`
+		Tags     []string `form:"tags,type=inputs"`
+	}{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, f.Fields["size"].Type, &Select{})
+	assert.Equal(t, f.Fields["foods"].Type, &SelectMultiple{})
+	assert.Equal(t, f.Fields["toppings"].Type, &CheckboxMultiple{})
+	assert.Equal(t, f.Fields["tags"].Type, &InputMultiple{})
+}
+
+func TestFromStructErrors(t *testing.T) {
+	_, err := FromStruct("string")
+	assert.Error(t, err)
+
+	_, err = FromStruct(struct {
+		Field string `form:"field,type=unknown"`
+	}{})
+	assert.Error(t, err)
+
+	_, err = FromStruct(struct {
+		Field string `validate:"unknown"`
+	}{})
+	assert.Error(t, err)
+
+	_, err = FromStruct(struct {
+		Field string `validate:"min=abc"`
+	}{})
+	assert.Error(t, err)
+}
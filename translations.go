@@ -1,15 +1,49 @@
 package forms
 
+// translations holds messages of validation errors, keyed by error code, and
+// labels. Placeholders in curly braces are replaced by error's value
+// ("{Value}") and parameters (ie. "{Min}").
 var translations map[string]string = map[string]string{
 	"REQUIRED": "This field can't be empty",
 
-	"INCORRECT_EMAIL": "\"%s\" is not correct email address",
+	"INCORRECT_EMAIL": "\"{Value}\" is not correct email address",
 
 	"INCORRECT_MULTI_VAL":  "You supplied more than one value for this field",
-	"INCORRECT_MIN_LENGTH": "Value \"%%s\" need to be at least %d chars long",
-	"INCORRECT_MAX_LENGTH": "Value \"%%s\" need to be at max %d chars long",
+	"INCORRECT_MIN_LENGTH": "Value \"{Value}\" need to be at least {Min} chars long",
+	"INCORRECT_MAX_LENGTH": "Value \"{Value}\" need to be at max {Max} chars long",
 
-	"NO_MATCH_PATTERN": "Value \"%%s\" doesn't match pattern \"%s\"",
+	"NO_MATCH_PATTERN": "Value \"{Value}\" doesn't match pattern \"{Pattern}\"",
 
-	"VALUE_NOT_FOUND": "Value \"%s\" not found in slice",
+	"VALUE_NOT_FOUND": "Value \"{Value}\" not found in slice",
+	"INVALID_CHOICE":  "Value \"{Value}\" is not one of available choices",
+
+	"FIELDS_NOT_EQUAL": "Value doesn't match field \"{Other}\"",
+
+	"MANAGEMENT_FORM_MISSING": "Management form data is missing or has been tampered with",
+	"TOO_FEW_FORMS":           "Please submit at least {Min} forms",
+	"TOO_MANY_FORMS":          "Please submit at most {Max} forms",
+	"DELETE_LABEL":            "Delete",
+	"ORDER_LABEL":             "Order",
+	"EMPTY_CHOICE_LABEL":      "---------",
+
+	"CSRF_INVALID": "Form has been tampered with or your session has changed, please submit it again",
+	"CSRF_EXPIRED": "Form has expired, please submit it again",
+
+	"FILE_TOO_BIG":    "File \"{Value}\" is too big, it can have at most {Max} bytes",
+	"FILE_EXTENSION":  "File \"{Value}\" has incorrect extension, allowed are: {Extensions}",
+	"FILE_TYPE":       "File \"{Value}\" has incorrect type \"{Type}\"",
+	"FILE_READ_ERROR": "File \"{Value}\" can't be read",
+	"TOO_MANY_FILES":  "You can upload at most {Max} files",
+
+	"TOO_FEW_SELECTED":  "Please select at least {Min} options",
+	"TOO_MANY_SELECTED": "Please select at most {Max} options",
+
+	"REQUEST_TOO_LARGE":        "Sent data is too large",
+	"REQUEST_INVALID":          "Sent data couldn't be read",
+	"UNSUPPORTED_CONTENT_TYPE": "Content type \"{Value}\" is not supported",
+
+	"INCORRECT_JSON_TYPE": "Incorrect type of value, expected {Type}",
+
+	"VALIDATION_CANCELED": "Validation was cancelled",
+	"VALIDATION_TIMEOUT":  "Validation took too long, please try again",
 }
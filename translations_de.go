@@ -0,0 +1,47 @@
+package forms
+
+// German is catalog with built-in German messages
+var German = &Catalog{Lang: "de", Messages: map[string]string{
+	"REQUIRED": "Dieses Feld darf nicht leer sein",
+
+	"INCORRECT_EMAIL": "\"{Value}\" ist keine gültige E-Mail-Adresse",
+
+	"INCORRECT_MULTI_VAL":  "Für dieses Feld wurde mehr als ein Wert angegeben",
+	"INCORRECT_MIN_LENGTH": "Der Wert \"{Value}\" muss mindestens {Min} Zeichen lang sein",
+	"INCORRECT_MAX_LENGTH": "Der Wert \"{Value}\" darf höchstens {Max} Zeichen lang sein",
+
+	"NO_MATCH_PATTERN": "Der Wert \"{Value}\" entspricht nicht dem Muster \"{Pattern}\"",
+
+	"VALUE_NOT_FOUND": "Der Wert \"{Value}\" ist nicht erlaubt",
+	"INVALID_CHOICE":  "Der Wert \"{Value}\" ist keine der verfügbaren Optionen",
+
+	"FIELDS_NOT_EQUAL": "Der Wert stimmt nicht mit dem Feld \"{Other}\" überein",
+
+	"MANAGEMENT_FORM_MISSING": "Daten des Verwaltungsformulars fehlen oder wurden manipuliert",
+	"TOO_FEW_FORMS":           "Bitte senden Sie mindestens {Min} Formulare",
+	"TOO_MANY_FORMS":          "Bitte senden Sie höchstens {Max} Formulare",
+	"DELETE_LABEL":            "Löschen",
+	"ORDER_LABEL":             "Reihenfolge",
+	"EMPTY_CHOICE_LABEL":      "---------",
+
+	"CSRF_INVALID": "Das Formular wurde manipuliert oder Ihre Sitzung hat sich geändert, bitte senden Sie es erneut",
+	"CSRF_EXPIRED": "Das Formular ist abgelaufen, bitte senden Sie es erneut",
+
+	"FILE_TOO_BIG":    "Die Datei \"{Value}\" ist zu groß, sie darf höchstens {Max} Bytes haben",
+	"FILE_EXTENSION":  "Die Datei \"{Value}\" hat eine ungültige Endung, erlaubt sind: {Extensions}",
+	"FILE_TYPE":       "Die Datei \"{Value}\" hat den ungültigen Typ \"{Type}\"",
+	"FILE_READ_ERROR": "Die Datei \"{Value}\" kann nicht gelesen werden",
+	"TOO_MANY_FILES":  "Sie können höchstens {Max} Dateien hochladen",
+
+	"TOO_FEW_SELECTED":  "Bitte wählen Sie mindestens {Min} Optionen",
+	"TOO_MANY_SELECTED": "Bitte wählen Sie höchstens {Max} Optionen",
+
+	"REQUEST_TOO_LARGE":        "Die gesendeten Daten sind zu groß",
+	"REQUEST_INVALID":          "Die gesendeten Daten konnten nicht gelesen werden",
+	"UNSUPPORTED_CONTENT_TYPE": "Der Inhaltstyp \"{Value}\" wird nicht unterstützt",
+
+	"INCORRECT_JSON_TYPE": "Ungültiger Typ des Wertes, erwartet wurde {Type}",
+
+	"VALIDATION_CANCELED": "Die Überprüfung wurde abgebrochen",
+	"VALIDATION_TIMEOUT":  "Die Überprüfung hat zu lange gedauert, bitte versuchen Sie es erneut",
+}}
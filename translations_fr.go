@@ -0,0 +1,47 @@
+package forms
+
+// French is catalog with built-in French messages
+var French = &Catalog{Lang: "fr", Messages: map[string]string{
+	"REQUIRED": "Ce champ ne peut pas être vide",
+
+	"INCORRECT_EMAIL": "« {Value} » n'est pas une adresse e-mail valide",
+
+	"INCORRECT_MULTI_VAL":  "Vous avez fourni plus d'une valeur pour ce champ",
+	"INCORRECT_MIN_LENGTH": "La valeur « {Value} » doit contenir au moins {Min} caractères",
+	"INCORRECT_MAX_LENGTH": "La valeur « {Value} » doit contenir au plus {Max} caractères",
+
+	"NO_MATCH_PATTERN": "La valeur « {Value} » ne correspond pas au motif « {Pattern} »",
+
+	"VALUE_NOT_FOUND": "La valeur « {Value} » n'est pas autorisée",
+	"INVALID_CHOICE":  "La valeur « {Value} » ne fait pas partie des choix disponibles",
+
+	"FIELDS_NOT_EQUAL": "La valeur ne correspond pas au champ « {Other} »",
+
+	"MANAGEMENT_FORM_MISSING": "Les données du formulaire de gestion sont manquantes ou ont été modifiées",
+	"TOO_FEW_FORMS":           "Veuillez envoyer au moins {Min} formulaires",
+	"TOO_MANY_FORMS":          "Veuillez envoyer au plus {Max} formulaires",
+	"DELETE_LABEL":            "Supprimer",
+	"ORDER_LABEL":             "Ordre",
+	"EMPTY_CHOICE_LABEL":      "---------",
+
+	"CSRF_INVALID": "Le formulaire a été modifié ou votre session a changé, veuillez le renvoyer",
+	"CSRF_EXPIRED": "Le formulaire a expiré, veuillez le renvoyer",
+
+	"FILE_TOO_BIG":    "Le fichier « {Value} » est trop volumineux, il peut avoir au plus {Max} octets",
+	"FILE_EXTENSION":  "Le fichier « {Value} » a une extension incorrecte, sont autorisées : {Extensions}",
+	"FILE_TYPE":       "Le fichier « {Value} » a un type incorrect « {Type} »",
+	"FILE_READ_ERROR": "Le fichier « {Value} » ne peut pas être lu",
+	"TOO_MANY_FILES":  "Vous pouvez envoyer au plus {Max} fichiers",
+
+	"TOO_FEW_SELECTED":  "Veuillez sélectionner au moins {Min} options",
+	"TOO_MANY_SELECTED": "Veuillez sélectionner au plus {Max} options",
+
+	"REQUEST_TOO_LARGE":        "Les données envoyées sont trop volumineuses",
+	"REQUEST_INVALID":          "Les données envoyées n'ont pas pu être lues",
+	"UNSUPPORTED_CONTENT_TYPE": "Le type de contenu « {Value} » n'est pas pris en charge",
+
+	"INCORRECT_JSON_TYPE": "Type de valeur incorrect, {Type} attendu",
+
+	"VALIDATION_CANCELED": "La validation a été annulée",
+	"VALIDATION_TIMEOUT":  "La validation a pris trop de temps, veuillez réessayer",
+}}
@@ -0,0 +1,83 @@
+package forms
+
+// Polish is catalog with built-in Polish messages
+var Polish = &Catalog{
+	Lang: "pl",
+	Messages: map[string]string{
+		"REQUIRED": "To pole nie może być puste",
+
+		"INCORRECT_EMAIL": "\"{Value}\" nie jest poprawnym adresem e-mail",
+
+		"INCORRECT_MULTI_VAL": "Podano więcej niż jedną wartość dla tego pola",
+
+		"NO_MATCH_PATTERN": "Wartość \"{Value}\" nie pasuje do wzorca \"{Pattern}\"",
+
+		"VALUE_NOT_FOUND": "Wartość \"{Value}\" nie jest dozwolona",
+		"INVALID_CHOICE":  "Wartość \"{Value}\" nie jest jedną z dostępnych opcji",
+
+		"FIELDS_NOT_EQUAL": "Wartość nie zgadza się z polem \"{Other}\"",
+
+		"MANAGEMENT_FORM_MISSING": "Brakuje danych formularza zarządzającego lub zostały one zmienione",
+		"DELETE_LABEL":            "Usuń",
+		"ORDER_LABEL":             "Kolejność",
+		"EMPTY_CHOICE_LABEL":      "---------",
+
+		"CSRF_INVALID": "Formularz został zmieniony lub sesja wygasła, prześlij go ponownie",
+		"CSRF_EXPIRED": "Formularz wygasł, prześlij go ponownie",
+
+		"FILE_EXTENSION":  "Plik \"{Value}\" ma niepoprawne rozszerzenie, dozwolone są: {Extensions}",
+		"FILE_TYPE":       "Plik \"{Value}\" ma niepoprawny typ \"{Type}\"",
+		"FILE_READ_ERROR": "Nie można odczytać pliku \"{Value}\"",
+
+		"REQUEST_TOO_LARGE":        "Przesłane dane są za duże",
+		"REQUEST_INVALID":          "Nie można odczytać przesłanych danych",
+		"UNSUPPORTED_CONTENT_TYPE": "Typ treści \"{Value}\" nie jest obsługiwany",
+
+		"INCORRECT_JSON_TYPE": "Niepoprawny typ wartości, oczekiwano {Type}",
+
+		"VALIDATION_CANCELED": "Walidacja została przerwana",
+		"VALIDATION_TIMEOUT":  "Walidacja trwała zbyt długo, spróbuj ponownie",
+	},
+	Plurals: map[string][]string{
+		"INCORRECT_MIN_LENGTH": {
+			"Wartość \"{Value}\" musi mieć co najmniej {Min} znak",
+			"Wartość \"{Value}\" musi mieć co najmniej {Min} znaki",
+			"Wartość \"{Value}\" musi mieć co najmniej {Min} znaków",
+		},
+		"INCORRECT_MAX_LENGTH": {
+			"Wartość \"{Value}\" może mieć co najwyżej {Max} znak",
+			"Wartość \"{Value}\" może mieć co najwyżej {Max} znaki",
+			"Wartość \"{Value}\" może mieć co najwyżej {Max} znaków",
+		},
+		"TOO_FEW_FORMS": {
+			"Prześlij co najmniej {Min} formularz",
+			"Prześlij co najmniej {Min} formularze",
+			"Prześlij co najmniej {Min} formularzy",
+		},
+		"TOO_MANY_FORMS": {
+			"Prześlij co najwyżej {Max} formularz",
+			"Prześlij co najwyżej {Max} formularze",
+			"Prześlij co najwyżej {Max} formularzy",
+		},
+		"FILE_TOO_BIG": {
+			"Plik \"{Value}\" jest za duży, może mieć co najwyżej {Max} bajt",
+			"Plik \"{Value}\" jest za duży, może mieć co najwyżej {Max} bajty",
+			"Plik \"{Value}\" jest za duży, może mieć co najwyżej {Max} bajtów",
+		},
+		"TOO_MANY_FILES": {
+			"Możesz przesłać co najwyżej {Max} plik",
+			"Możesz przesłać co najwyżej {Max} pliki",
+			"Możesz przesłać co najwyżej {Max} plików",
+		},
+		"TOO_FEW_SELECTED": {
+			"Wybierz co najmniej {Min} opcję",
+			"Wybierz co najmniej {Min} opcje",
+			"Wybierz co najmniej {Min} opcji",
+		},
+		"TOO_MANY_SELECTED": {
+			"Wybierz co najwyżej {Max} opcję",
+			"Wybierz co najwyżej {Max} opcje",
+			"Wybierz co najwyżej {Max} opcji",
+		},
+	},
+}
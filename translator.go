@@ -0,0 +1,128 @@
+package forms
+
+import (
+	"strings"
+	"sync"
+)
+
+// Translator returns message with given key, keys are codes of validation
+// errors (ie. "REQUIRED") or labels (ie. "DELETE_LABEL")
+type Translator interface {
+	Translate(key string) (string, bool)
+}
+
+// TranslatorFunc is adapter that allows to use function as translator
+type TranslatorFunc func(key string) (string, bool)
+
+// Translate calls f(key)
+func (f TranslatorFunc) Translate(key string) (string, bool) {
+	return f(key)
+}
+
+// Catalog is translator with messages in given language. Messages use the
+// same placeholders as English ones, ie. "{Value}" or "{Min}".
+type Catalog struct {
+	// Language tag, ie. "pl" or "pt-BR"
+	Lang     string
+	Messages map[string]string
+	// Plural forms of messages, form is chosen by PluralRule
+	Plurals map[string][]string
+	// PluralRule returns index of plural form for given count, rule of
+	// catalog's language is used when it's nil
+	PluralRule PluralRule
+}
+
+// Translate returns message with given key, when there are only plural forms
+// of message the last one is used
+func (c *Catalog) Translate(key string) (string, bool) {
+	if msg, ok := c.Messages[key]; ok {
+		return msg, true
+	}
+	if forms := c.Plurals[key]; len(forms) > 0 {
+		return forms[len(forms)-1], true
+	}
+
+	return "", false
+}
+
+// TranslatePlural returns form of message with given key that matches given
+// count, message without plural forms is returned as it is
+func (c *Catalog) TranslatePlural(key string, n int) (string, bool) {
+	forms := c.Plurals[key]
+	if len(forms) == 0 {
+		return c.Translate(key)
+	}
+
+	rule := c.PluralRule
+	if rule == nil {
+		rule = pluralRuleFor(c.Lang)
+	}
+
+	return forms[clampIndex(rule(n), len(forms)-1)], true
+}
+
+// English is catalog with built-in English messages, it's used when message
+// can't be found in form's translator
+var English = &Catalog{Lang: "en", Messages: translations}
+
+// DefaultTranslator is used by forms without translator
+var DefaultTranslator Translator = English
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]*Catalog{}
+)
+
+func init() {
+	for _, catalog := range []*Catalog{English, Polish, German, French} {
+		RegisterCatalog(catalog)
+	}
+}
+
+// normalizeLang returns language tag in form used as key in catalogs
+func normalizeLang(lang string) string {
+	return strings.ToLower(strings.Replace(lang, "_", "-", -1))
+}
+
+// RegisterCatalog adds catalog to catalogs available by LookupCatalog,
+// catalog with the same language is replaced
+func RegisterCatalog(catalog *Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	catalogs[normalizeLang(catalog.Lang)] = catalog
+}
+
+// LookupCatalog returns registered catalog for given language tag, tags are
+// case insensitive
+func LookupCatalog(lang string) (*Catalog, bool) {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	catalog, ok := catalogs[normalizeLang(lang)]
+	return catalog, ok
+}
+
+// translate returns message with given key, taken from given translator, when
+// it's missing English message is used, or key itself
+func translate(t Translator, key string) string {
+	if t != nil {
+		if msg, ok := t.Translate(key); ok {
+			return msg
+		}
+	}
+	if msg, ok := English.Translate(key); ok {
+		return msg
+	}
+
+	return key
+}
+
+// translator returns translator used by form
+func (f *Form) translator() Translator {
+	if f != nil && f.Translator != nil {
+		return f.Translator
+	}
+
+	return DefaultTranslator
+}
@@ -0,0 +1,85 @@
+package forms
+
+import (
+	"html/template"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinCatalogs(t *testing.T) {
+	for _, lang := range []string{"en", "pl", "de", "fr"} {
+		catalog, ok := LookupCatalog(lang)
+		assert.True(t, ok, "Catalog %s should be registered", lang)
+		for key := range translations {
+			_, ok := catalog.Translate(key)
+			assert.True(t, ok, "Catalog %s is missing %s", lang, key)
+		}
+	}
+
+	catalog, ok := LookupCatalog("PL")
+	assert.True(t, ok)
+	assert.Equal(t, catalog, Polish)
+
+	_, ok = LookupCatalog("xx")
+	assert.False(t, ok)
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	catalog := &Catalog{Lang: "pt_BR", Messages: map[string]string{"REQUIRED": "Campo obrigatório"}}
+	RegisterCatalog(catalog)
+	defer func() {
+		catalogsMu.Lock()
+		delete(catalogs, "pt-br")
+		catalogsMu.Unlock()
+	}()
+
+	found, ok := LookupCatalog("pt-br")
+	assert.True(t, ok)
+	assert.Equal(t, found, catalog)
+}
+
+func TestValidationErrorTranslate(t *testing.T) {
+	err := NewValidationError("INCORRECT_MIN_LENGTH", "foo", Params{"Min": 4})
+	assert.Equal(t, err.Translate(Polish), `Wartość "foo" musi mieć co najmniej 4 znaki`)
+	assert.Equal(t, err.Translate(nil), err.Error())
+
+	missing := &Catalog{Lang: "xx", Messages: map[string]string{}}
+	assert.Equal(t, err.Translate(missing), err.Error(), "English message should be used when it's missing")
+
+	err = &ValidationError{Code: "REQUIRED", Message: "Custom"}
+	assert.Equal(t, err.Translate(Polish), "Custom")
+}
+
+func TestFormTranslator(t *testing.T) {
+	f := New(map[string]*Field{
+		"name": &Field{Label: "Name", Validators: []Validator{&Required{}}},
+	}, nil)
+	f.Translator = TranslatorFunc(func(key string) (string, bool) {
+		msg, ok := map[string]string{"Name": "Imię", "REQUIRED": "Wymagane"}[key]
+		return msg, ok
+	})
+
+	assert.False(t, f.IsValid(url.Values{}))
+	assert.Equal(t, f.Fields["name"].RenderErrors(), template.HTML("<ul class=\"errors\">\n<li>Wymagane</li>\n</ul>"))
+	assert.Equal(t, f.Fields["name"].RenderLabel(), template.HTML(`<label for="f_name">Imię</label>`))
+	assert.Equal(t, f.Problem(0).InvalidParams[0].Reason, "Wymagane")
+
+	f.AddValidationError(NewValidationError("CSRF_EXPIRED", "", nil))
+	assert.Equal(t, f.RenderErrors(), template.HTML("<ul class=\"errors\">\n<li>"+translations["CSRF_EXPIRED"]+"</li>\n</ul>"))
+
+	f.Translator = nil
+	assert.Equal(t, f.Fields["name"].RenderLabel(), template.HTML(`<label for="f_name">Name</label>`))
+}
+
+func TestFormSetTranslator(t *testing.T) {
+	fs := newItemFormSet()
+	fs.CanDelete = true
+	fs.Form.Translator = German
+	fs.SetInitial(nil)
+
+	assert.Contains(t, string(fs.Forms[0].Fields[DeletionFieldName].RenderLabel()), "Löschen")
+	assert.False(t, fs.IsValid(url.Values{}))
+	assert.Contains(t, string(fs.RenderErrors()), "Verwaltungsformulars")
+}
@@ -3,6 +3,7 @@ package forms
 import (
 	"fmt"
 	"html/template"
+	"mime/multipart"
 	"strconv"
 )
 
@@ -42,39 +43,82 @@ func (i *Input) CleanData(values []string) interface{} {
 
 // Render returns string with rendered basic input
 func (i *Input) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "input", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "input", noUseAttrs, vs)
 }
 
-// Radio is radio input type
-type Radio struct{}
+// Radio is radio input type, it accepts single value from choices. If Empty
+// is set, choice with empty value is added before choices, its label is
+// EmptyLabel or "EMPTY_CHOICE_LABEL" translation.
+type Radio struct {
+	Empty      bool
+	EmptyLabel string
+}
 
 // IsMultiValue returns if radio input allow multiple values
 func (i *Radio) IsMultiValue() bool {
-	return true
+	return false
 }
 
 // CleanData returns cleaned values for radio
 func (i *Radio) CleanData(values []string) interface{} {
-	return values
+	if len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
 }
 
-// Render returns string with rendered radio input
+// Render returns string with rendered radio input, with radio of value
+// checked
 func (i *Radio) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	field := ""
-	attrs := Attributes{}
-	for _, c := range cs {
-		for k, v := range f.Attributes {
-			attrs[k] = v
-		}
-		attrs["id"] = fmt.Sprintf("c_%s_%s", f.Name, c.Value)
-		field = field + fmt.Sprintf(
-			"<label for=\"c_%s_%s\">%s %s</label>\n",
-			f.Name, c.Value,
-			renderInput(attrs, f.Name, "radio", noUseAttrs, []string{c.Value}),
-			c.Label,
-		)
+	if i.Empty {
+		cs = withEmptyChoice(f, cs, i.EmptyLabel)
+	}
+
+	return renderChoiceInputs(f, "radio", cs, firstValue(vs))
+}
+
+// Select is select type, it accepts single value from choices. If Empty is
+// set, option with empty value is added before choices, its label is
+// EmptyLabel or "EMPTY_CHOICE_LABEL" translation.
+type Select struct {
+	*Input
+	Empty      bool
+	EmptyLabel string
+}
+
+// Render returns string with rendered select, with option of value selected
+func (t *Select) Render(f *Field, cs []Choice, vs []string) template.HTML {
+	if t.Empty {
+		cs = withEmptyChoice(f, cs, t.EmptyLabel)
 	}
-	return template.HTML(field)
+
+	return renderSelect(f.Attributes, f.HTMLName(), noUseAttrs, cs, firstValue(vs))
+}
+
+// SelectMultiple is select type that accepts many values from choices
+type SelectMultiple struct{}
+
+// IsMultiValue returns if multiple select allow multiple values
+func (t *SelectMultiple) IsMultiValue() bool {
+	return true
+}
+
+// CleanData returns slice of selected values
+func (t *SelectMultiple) CleanData(values []string) interface{} {
+	return values
+}
+
+// Render returns string with rendered select with multiple attribute, with
+// options of values selected
+func (t *SelectMultiple) Render(f *Field, cs []Choice, vs []string) template.HTML {
+	attrs := copyAttributes(f.Attributes)
+	if attrs == nil {
+		attrs = Attributes{}
+	}
+	attrs["multiple"] = "multiple"
+
+	return renderSelect(attrs, f.HTMLName(), noUseAttrs, cs, vs)
 }
 
 // Textarea is textarea type
@@ -90,7 +134,7 @@ func (t *Textarea) Render(f *Field, cs []Choice, vs []string) template.HTML {
 	}
 
 	return template.HTML(fmt.Sprintf(
-		"<textarea id=\"f_%s\" name=\"%s\"%s>%s</textarea>", f.Name, f.Name,
+		"<textarea id=\"f_%s\" name=\"%s\"%s>%s</textarea>", f.HTMLName(), f.HTMLName(),
 		prepareAttributes(f.Attributes, noUseAttrs), value,
 	))
 }
@@ -122,7 +166,7 @@ func (t *InputNumber) CleanData(values []string) interface{} {
 
 // Render returns string with rendered number input
 func (t *InputNumber) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "number", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "number", noUseAttrs, vs)
 }
 
 // Checkbox is checkbox input type
@@ -141,18 +185,36 @@ func (t *Checkbox) CleanData(values []string) interface{} {
 
 // Render returns string with rendered checkbox input
 func (t *Checkbox) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	var attrs Attributes
-	if f.Attributes == nil {
+	attrs := copyAttributes(f.Attributes)
+	if attrs == nil {
 		attrs = Attributes{}
-	} else {
-		attrs = f.Attributes
 	}
 
 	if len(vs) > 0 && vs[0] != "" {
 		attrs["checked"] = "checked"
 	}
 
-	return renderInput(attrs, f.Name, "checkbox", noUseAttrs, nil)
+	return renderInput(attrs, f.HTMLName(), "checkbox", noUseAttrs, nil)
+}
+
+// CheckboxMultiple is group of checkboxes, one for each choice, it accepts
+// many values
+type CheckboxMultiple struct{}
+
+// IsMultiValue returns if checkbox group allow multiple values
+func (t *CheckboxMultiple) IsMultiValue() bool {
+	return true
+}
+
+// CleanData returns slice of checked values
+func (t *CheckboxMultiple) CleanData(values []string) interface{} {
+	return values
+}
+
+// Render returns string with rendered checkboxes, checkboxes of values are
+// checked
+func (t *CheckboxMultiple) Render(f *Field, cs []Choice, vs []string) template.HTML {
+	return renderChoiceInputs(f, "checkbox", cs, vs)
 }
 
 // InputEmail is email input type
@@ -162,7 +224,7 @@ type InputEmail struct {
 
 // Render returns string with rendered email input
 func (t *InputEmail) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "email", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "email", noUseAttrs, vs)
 }
 
 // InputPassword is password input type
@@ -172,7 +234,7 @@ type InputPassword struct {
 
 // Render returns string with rendered password input
 func (t *InputPassword) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "password", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "password", noUseAttrs, vs)
 }
 
 // InputDate is date input type
@@ -182,7 +244,7 @@ type InputDate struct {
 
 // Render returns string with rendered date input
 func (t *InputDate) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "date", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "date", noUseAttrs, vs)
 }
 
 // InputTime is date input type
@@ -192,7 +254,7 @@ type InputTime struct {
 
 // Render returns string with rendered time input
 func (t *InputTime) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "time", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "time", noUseAttrs, vs)
 }
 
 // InputDateTime is date datetime (uses datetime-local) input type
@@ -202,7 +264,7 @@ type InputDateTime struct {
 
 // Render returns string with rendered datetime input
 func (t *InputDateTime) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "datetime-local", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "datetime-local", noUseAttrs, vs)
 }
 
 // InputMonth is month input type
@@ -212,7 +274,7 @@ type InputMonth struct {
 
 // Render returns string with rendered month input
 func (t *InputMonth) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "month", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "month", noUseAttrs, vs)
 }
 
 // InputWeek is week input type
@@ -222,7 +284,7 @@ type InputWeek struct {
 
 // Render returns string with rendered week input
 func (t *InputWeek) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "week", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "week", noUseAttrs, vs)
 }
 
 // InputURL is url input type
@@ -232,7 +294,7 @@ type InputURL struct {
 
 // Render returns string with rendered url input
 func (t *InputURL) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "url", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "url", noUseAttrs, vs)
 }
 
 // InputTel is tel input type
@@ -242,7 +304,7 @@ type InputTel struct {
 
 // Render returns string with rendered tel input
 func (t *InputTel) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "tel", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "tel", noUseAttrs, vs)
 }
 
 // InputSearch is tel search type
@@ -252,5 +314,124 @@ type InputSearch struct {
 
 // Render returns string with rendered search input
 func (t *InputSearch) Render(f *Field, cs []Choice, vs []string) template.HTML {
-	return renderInput(f.Attributes, f.Name, "search", noUseAttrs, vs)
+	return renderInput(f.Attributes, f.HTMLName(), "search", noUseAttrs, vs)
+}
+
+// InputHidden is hidden input type, with Multiple set it accepts many values
+// and renders input for each of them. Errors of hidden fields are moved to
+// form's errors, as users can't see the field.
+type InputHidden struct {
+	Multiple bool
+}
+
+// IsMultiValue returns if hidden input allow multiple values
+func (t *InputHidden) IsMultiValue() bool {
+	return t.Multiple
+}
+
+// CleanData returns cleaned values for hidden input, string or slice of
+// strings when Multiple is set
+func (t *InputHidden) CleanData(values []string) interface{} {
+	if t.Multiple {
+		return values
+	}
+	if len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// Render returns string with rendered hidden input, or inputs for every value
+// when Multiple is set
+func (t *InputHidden) Render(f *Field, cs []Choice, vs []string) template.HTML {
+	if !t.Multiple {
+		return renderInput(f.Attributes, f.HTMLName(), "hidden", noUseAttrs, vs)
+	}
+
+	return renderInputs(f, "hidden", vs)
+}
+
+// InputMultiple is input type that accepts many values, it renders input for
+// each of them, or one empty input when there are no values
+type InputMultiple struct{}
+
+// IsMultiValue returns if multiple input allow multiple values
+func (t *InputMultiple) IsMultiValue() bool {
+	return true
+}
+
+// CleanData returns slice of entered values
+func (t *InputMultiple) CleanData(values []string) interface{} {
+	return values
+}
+
+// Render returns string with rendered inputs for every value
+func (t *InputMultiple) Render(f *Field, cs []Choice, vs []string) template.HTML {
+	if len(vs) == 0 {
+		vs = []string{""}
+	}
+
+	return renderInputs(f, "input", vs)
+}
+
+// FileType is interface of types that accept uploaded files, they receive
+// files when form is validated by IsValidMultipart
+type FileType interface {
+	Type
+	// Cleans uploaded files before they go to user
+	CleanFiles(files []*multipart.FileHeader) interface{}
+}
+
+// File is file input type, it accepts single file
+type File struct {
+	*Input
+}
+
+// CleanData returns nil, as file can't be sent without multipart form
+func (t *File) CleanData(values []string) interface{} {
+	return nil
+}
+
+// CleanFiles returns uploaded file (*multipart.FileHeader) or nil
+func (t *File) CleanFiles(files []*multipart.FileHeader) interface{} {
+	if len(files) > 0 {
+		return files[0]
+	}
+
+	return nil
+}
+
+// Render returns string with rendered file input
+func (t *File) Render(f *Field, cs []Choice, vs []string) template.HTML {
+	return renderInput(f.Attributes, f.HTMLName(), "file", noUseAttrs, nil)
+}
+
+// MultipleFile is file input type that accepts many files
+type MultipleFile struct{}
+
+// IsMultiValue returns if multiple file input allow multiple values
+func (t *MultipleFile) IsMultiValue() bool {
+	return true
+}
+
+// CleanData returns nil, as files can't be sent without multipart form
+func (t *MultipleFile) CleanData(values []string) interface{} {
+	return []*multipart.FileHeader(nil)
+}
+
+// CleanFiles returns slice of uploaded files
+func (t *MultipleFile) CleanFiles(files []*multipart.FileHeader) interface{} {
+	return files
+}
+
+// Render returns string with rendered file input with multiple attribute
+func (t *MultipleFile) Render(f *Field, cs []Choice, vs []string) template.HTML {
+	attrs := copyAttributes(f.Attributes)
+	if attrs == nil {
+		attrs = Attributes{}
+	}
+	attrs["multiple"] = "multiple"
+
+	return renderInput(attrs, f.HTMLName(), "file", noUseAttrs, nil)
 }
@@ -61,20 +61,20 @@ func TestTypeInput(t *testing.T) {
 func TestTypeRadio(t *testing.T) {
 	var resultsSet = TypeTestsSet{
 		name:       "Radio",
-		multiValue: true,
+		multiValue: false,
 
 		results: TypeTestsResults{
-			{[]string{"accdddaabbcce"}, []string{"accdddaabbcce"}},
-			{[]string{"a", "b"}, []string{"a", "b"}},
-			{[]string{""}, []string{""}},
-			{nil, []string(nil)},
+			{[]string{"accdddaabbcce"}, "accdddaabbcce"},
+			{[]string{"a", "b"}, "a"},
+			{[]string{""}, ""},
+			{nil, ""},
 		},
 	}
 
 	choices := []Choice{
-		{"pizza", "Pizza"},
-		{"pasta", "Makaron"},
-		{"risotto", "Risotto"},
+		{Value: "pizza", Label: "Pizza"},
+		{Value: "pasta", Label: "Makaron"},
+		{Value: "risotto", Label: "Risotto"},
 	}
 
 	_t := &Radio{}
@@ -152,6 +152,114 @@ func TestTypeRadio(t *testing.T) {
 
 	// Empty choices
 	assert.Equal(t, _t.Render(f, nil, []string{}), template.HTML(""))
+
+	// Selected value
+	f.Attributes = nil
+	r = strings.Split(string(_t.Render(f, choices, []string{"pasta", "risotto"})), "\n")
+	assert.NotContains(t, r[0], " checked=\"checked\"")
+	assert.Contains(t, r[1], " checked=\"checked\"")
+	assert.NotContains(t, r[2], " checked=\"checked\"", "Only first value should be checked")
+
+	// Empty choice
+	_t = &Radio{Empty: true}
+	r = strings.Split(string(_t.Render(f, choices, []string{""})), "\n")
+	assert.Len(t, r, 5)
+	assert.True(t, strings.HasPrefix(r[0], "<label for=\"c_test1_\"><input "))
+	assert.Contains(t, r[0], " value=\"\" ")
+	assert.Contains(t, r[0], " checked=\"checked\"")
+	assert.True(t, strings.HasSuffix(r[0], " /> ---------</label>"))
+
+	// Labels are escaped like in Select
+	_t = &Radio{}
+	r = strings.Split(string(_t.Render(f, []Choice{{Value: "sushi", Label: "Sushi & rolls"}}, nil)), "\n")
+	assert.True(t, strings.HasSuffix(r[0], " /> Sushi &amp; rolls</label>"))
+	assert.Contains(t, (&Select{}).Render(f, []Choice{{Value: "sushi", Label: "Sushi & rolls"}}, nil), ">Sushi &amp; rolls</option>")
+
+	_t = &Radio{Empty: true, EmptyLabel: "ORDER_LABEL"}
+	f.form = &Form{Translator: Polish}
+	r = strings.Split(string(_t.Render(f, choices, nil)), "\n")
+	assert.True(t, strings.HasSuffix(r[0], " /> Kolejność</label>"), "Empty label should be translated")
+	assert.NotContains(t, r[0], "checked")
+}
+
+func TestTypeSelect(t *testing.T) {
+	var resultsSet = TypeTestsSet{
+		name:       "Select",
+		multiValue: false,
+
+		results: TypeTestsResults{
+			{[]string{"pizza"}, "pizza"},
+			{[]string{""}, ""},
+			{nil, ""},
+		},
+	}
+
+	choices := []Choice{
+		{Value: "", Label: "---"},
+		{Label: "Italian", Choices: []Choice{
+			{Value: "pizza", Label: "Pizza"},
+			{Value: "pasta", Label: "Pasta", Disabled: true},
+		}},
+		{Value: "sushi", Label: "Sushi & rolls"},
+	}
+
+	_t := &Select{}
+	f := &Field{Name: "test1", Type: _t}
+	executeTypeTests(t, _t, resultsSet)
+
+	assert.Equal(t, _t.Render(f, choices, []string{"pizza"}), template.HTML(
+		"<select name=\"test1\" id=\"f_test1\">\n"+
+			"<option value=\"\">---</option>\n"+
+			"<optgroup label=\"Italian\">\n"+
+			"<option value=\"pizza\" selected=\"selected\">Pizza</option>\n"+
+			"<option value=\"pasta\" disabled=\"disabled\">Pasta</option>\n"+
+			"</optgroup>\n"+
+			"<option value=\"sushi\">Sushi &amp; rolls</option>\n"+
+			"</select>",
+	))
+
+	f.Attributes = Attributes{"id": "food"}
+	r := _t.Render(f, choices, nil)
+	assert.True(t, strings.HasPrefix(string(r), "<select name=\"test1\" id=\"food\">\n"))
+	assert.NotContains(t, r, "selected")
+
+	_t = &Select{Empty: true, EmptyLabel: "Choose food"}
+	r = _t.Render(f, choices[1:], []string{"sushi", "pizza"})
+	assert.True(t, strings.HasPrefix(string(r), "<select name=\"test1\" id=\"food\">\n<option value=\"\">Choose food</option>\n"))
+	assert.Contains(t, r, "<option value=\"sushi\" selected=\"selected\">")
+	assert.Contains(t, r, "<option value=\"pizza\">")
+}
+
+func TestTypeSelectMultiple(t *testing.T) {
+	var resultsSet = TypeTestsSet{
+		name:       "SelectMultiple",
+		multiValue: true,
+
+		results: TypeTestsResults{
+			{[]string{"a", "b"}, []string{"a", "b"}},
+			{[]string{""}, []string{""}},
+			{nil, []string(nil)},
+		},
+	}
+
+	choices := []Choice{
+		{Value: "pizza", Label: "Pizza"},
+		{Value: "pasta", Label: "Pasta"},
+		{Value: "sushi", Label: "Sushi"},
+	}
+
+	_t := &SelectMultiple{}
+	f := &Field{Name: "test1", Type: _t, Attributes: Attributes{"class": "food"}}
+	executeTypeTests(t, _t, resultsSet)
+
+	r := _t.Render(f, choices, []string{"pizza", "sushi"})
+	assert.True(t, strings.HasPrefix(string(r), "<select name=\"test1\" "))
+	assert.Contains(t, r, " multiple=\"multiple\"")
+	assert.Contains(t, r, " class=\"food\"")
+	assert.Contains(t, r, "<option value=\"pizza\" selected=\"selected\">Pizza</option>")
+	assert.Contains(t, r, "<option value=\"pasta\">Pasta</option>")
+	assert.Contains(t, r, "<option value=\"sushi\" selected=\"selected\">Sushi</option>")
+	assert.Equal(t, f.Attributes, Attributes{"class": "food"})
 }
 
 func TestTypeTextarea(t *testing.T) {
@@ -278,6 +386,49 @@ func TestTypeChecbox(t *testing.T) {
 	assert.Contains(t, rendered, " checked=\"checked\" ")
 	assert.Contains(t, rendered, " id=\"f_test1\" ")
 	assert.True(t, strings.HasSuffix(string(rendered), " />"))
+	assert.Equal(t, f.Attributes, Attributes{"test": "ok"}, "Rendering shouldn't modify attributes")
+}
+
+func TestTypeCheckboxMultiple(t *testing.T) {
+	var resultsSet = TypeTestsSet{
+		name:       "CheckboxMultiple",
+		multiValue: true,
+
+		results: TypeTestsResults{
+			{[]string{"a", "b"}, []string{"a", "b"}},
+			{nil, []string(nil)},
+		},
+	}
+
+	choices := []Choice{
+		{Value: "pizza", Label: "Pizza"},
+		{Label: "Other", Choices: []Choice{
+			{Value: "pasta", Label: "Makaron", Disabled: true},
+			{Value: "risotto", Label: "Risotto"},
+		}},
+	}
+
+	_t := &CheckboxMultiple{}
+	f := &Field{Name: "test1", Type: _t, Attributes: Attributes{"class": "food"}}
+	executeTypeTests(t, _t, resultsSet)
+
+	r := strings.Split(string(_t.Render(f, choices, []string{"pizza", "risotto"})), "\n")
+	assert.Len(t, r, 4)
+
+	assert.True(t, strings.HasPrefix(r[0], "<label for=\"c_test1_pizza\"><input name=\"test1\" type=\"checkbox\" "))
+	assert.Contains(t, r[0], " id=\"c_test1_pizza\"")
+	assert.Contains(t, r[0], " class=\"food\"")
+	assert.Contains(t, r[0], " checked=\"checked\"")
+	assert.Contains(t, r[0], " value=\"pizza\"")
+	assert.True(t, strings.HasSuffix(r[0], " /> Pizza</label>"))
+
+	assert.NotContains(t, r[1], "checked")
+	assert.Contains(t, r[1], " disabled=\"disabled\"")
+	assert.True(t, strings.HasSuffix(r[1], " /> Makaron</label>"))
+
+	assert.Contains(t, r[2], " checked=\"checked\"")
+	assert.Equal(t, r[3], "")
+	assert.Equal(t, f.Attributes, Attributes{"class": "food"})
 }
 
 func TestTypeInputEmail(t *testing.T) {
@@ -375,3 +526,64 @@ func TestTypeInputSearch(t *testing.T) {
 	f := &Field{Name: "test", Type: _t}
 	assert.Equal(t, _t.Render(f, nil, []string{}), template.HTML("<input name=\"test\" type=\"search\" id=\"f_test\" />"))
 }
+
+func TestTypeInputHidden(t *testing.T) {
+	executeTypeTests(t, &InputHidden{}, TypeTestsSet{
+		name:       "InputHidden",
+		multiValue: false,
+
+		results: TypeTestsResults{
+			{[]string{"abc"}, "abc"},
+			{nil, ""},
+		},
+	})
+
+	_t := &InputHidden{}
+	f := &Field{Name: "test", Type: _t}
+	assert.Equal(t, _t.Render(f, nil, []string{"abc"}), template.HTML("<input name=\"test\" type=\"hidden\" id=\"f_test\" value=\"abc\" />"))
+	assert.Equal(t, _t.Render(f, nil, nil), template.HTML("<input name=\"test\" type=\"hidden\" id=\"f_test\" />"))
+}
+
+func TestTypeInputMultiple(t *testing.T) {
+	executeTypeTests(t, &InputMultiple{}, TypeTestsSet{
+		name:       "InputMultiple",
+		multiValue: true,
+
+		results: TypeTestsResults{
+			{[]string{"a", "b"}, []string{"a", "b"}},
+			{nil, []string(nil)},
+		},
+	})
+
+	_t := &InputMultiple{}
+	f := &Field{Name: "test", Type: _t}
+	assert.Equal(t, _t.Render(f, nil, []string{"a", "b"}), template.HTML(
+		"<input name=\"test\" type=\"input\" id=\"f_test_0\" value=\"a\" />\n"+
+			"<input name=\"test\" type=\"input\" id=\"f_test_1\" value=\"b\" />\n",
+	))
+	assert.Equal(t, _t.Render(f, nil, nil), template.HTML("<input name=\"test\" type=\"input\" id=\"f_test_0\" />\n"))
+}
+
+func TestTypeInputHiddenMultiple(t *testing.T) {
+	executeTypeTests(t, &InputHidden{Multiple: true}, TypeTestsSet{
+		name:       "InputHidden",
+		multiValue: true,
+
+		results: TypeTestsResults{
+			{[]string{"a", "b"}, []string{"a", "b"}},
+			{nil, []string(nil)},
+		},
+	})
+
+	_t := &InputHidden{Multiple: true}
+	f := &Field{Name: "test", Type: _t}
+	assert.Equal(t, _t.Render(f, nil, []string{"a", "b"}), template.HTML(
+		"<input name=\"test\" type=\"hidden\" id=\"f_test_0\" value=\"a\" />\n"+
+			"<input name=\"test\" type=\"hidden\" id=\"f_test_1\" value=\"b\" />\n",
+	))
+	assert.Equal(t, _t.Render(f, nil, nil), template.HTML(""))
+
+	f.Attributes = Attributes{"id": "ids"}
+	assert.Equal(t, _t.Render(f, nil, []string{"a"}), template.HTML("<input name=\"test\" type=\"hidden\" id=\"ids_0\" value=\"a\" />\n"))
+	assert.Equal(t, f.Attributes, Attributes{"id": "ids"})
+}
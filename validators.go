@@ -1,9 +1,14 @@
 package forms
 
 import (
-	"fmt"
-	"html"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"strings"
 )
 
 const (
@@ -20,34 +25,34 @@ func patternMatched(pattern, value string) bool {
 
 type checkFunc func(string) bool
 
-func validate(fn checkFunc, values []string, msg string) (bool, []string) {
+func validate(fn checkFunc, values []string, code string, params Params) (bool, []*ValidationError) {
 	result := true
-	msgs := []string{}
+	errs := []*ValidationError{}
 	for _, value := range values {
 		if value != "" && !fn(value) {
 			result = false
-			msgs = append(msgs, html.EscapeString(fmt.Sprintf(msg, value)))
+			errs = append(errs, NewValidationError(code, value, params))
 		}
 	}
 
-	return result, msgs
+	return result, errs
 }
 
 // Validator is interface for all validators
 type Validator interface {
-	IsValid(values []string) (bool, []string)
+	IsValid(values []string) (bool, []*ValidationError)
 }
 
 // Required is validator that require some data input
 type Required struct{}
 
 // IsValid checks is entered data are correct
-func (r *Required) IsValid(values []string) (bool, []string) {
+func (r *Required) IsValid(values []string) (bool, []*ValidationError) {
 	if len(values) > 0 && len(values[0]) > 0 {
-		return true, []string{}
+		return true, []*ValidationError{}
 	}
 
-	return false, []string{html.EscapeString(translations["REQUIRED"])}
+	return false, []*ValidationError{NewValidationError("REQUIRED", "", nil)}
 }
 
 // Regexp validator checks if given value match pattern
@@ -57,20 +62,20 @@ type Regexp struct {
 }
 
 // IsValid checks is entered data are correct
-func (r *Regexp) IsValid(values []string) (bool, []string) {
+func (r *Regexp) IsValid(values []string) (bool, []*ValidationError) {
 	return validate(func(value string) bool {
 		return patternMatched(r.Pattern, value)
-	}, values, fmt.Sprintf(translations["NO_MATCH_PATTERN"], r.Pattern))
+	}, values, "NO_MATCH_PATTERN", Params{"Pattern": r.Pattern})
 }
 
 // Email validator checks if given value is proper email
 type Email struct{}
 
 // IsValid checks is entered data are correct
-func (v *Email) IsValid(values []string) (bool, []string) {
+func (v *Email) IsValid(values []string) (bool, []*ValidationError) {
 	return validate(func(value string) bool {
 		return patternMatched(emailPattern, value)
-	}, values, translations["INCORRECT_EMAIL"])
+	}, values, "INCORRECT_EMAIL", nil)
 }
 
 // MinLength validator checks if given values length is under value
@@ -80,10 +85,10 @@ type MinLength struct {
 }
 
 // IsValid checks is entered data are correct
-func (v *MinLength) IsValid(values []string) (bool, []string) {
+func (v *MinLength) IsValid(values []string) (bool, []*ValidationError) {
 	return validate(func(value string) bool {
 		return len(value) >= v.Min
-	}, values, fmt.Sprintf(translations["INCORRECT_MIN_LENGTH"], v.Min))
+	}, values, "INCORRECT_MIN_LENGTH", Params{"Min": v.Min})
 }
 
 // MaxLength validator checks if given values length doesn't exceed given value
@@ -93,10 +98,10 @@ type MaxLength struct {
 }
 
 // IsValid checks is entered data are correct
-func (v *MaxLength) IsValid(values []string) (bool, []string) {
+func (v *MaxLength) IsValid(values []string) (bool, []*ValidationError) {
 	return validate(func(value string) bool {
 		return len(value) <= v.Max
-	}, values, fmt.Sprintf(translations["INCORRECT_MAX_LENGTH"], v.Max))
+	}, values, "INCORRECT_MAX_LENGTH", Params{"Max": v.Max})
 }
 
 // InSlice validator checks if given value is in slice
@@ -106,8 +111,323 @@ type InSlice struct {
 }
 
 // IsValid checks is entered data are correct
-func (v *InSlice) IsValid(values []string) (bool, []string) {
+func (v *InSlice) IsValid(values []string) (bool, []*ValidationError) {
 	return validate(func(value string) bool {
 		return valueInSlice(value, v.Values)
-	}, values, translations["VALUE_NOT_FOUND"])
+	}, values, "VALUE_NOT_FOUND", nil)
+}
+
+// countSelected returns number of non empty values
+func countSelected(values []string) int {
+	count := 0
+	for _, value := range values {
+		if value != "" {
+			count++
+		}
+	}
+
+	return count
+}
+
+// MinSelected validator checks if at least given number of options is
+// selected, field without selected options is valid, use Required to reject it
+//     validator := &MinSelected{2}
+type MinSelected struct {
+	Min int
+}
+
+// IsValid checks is entered data are correct
+func (v *MinSelected) IsValid(values []string) (bool, []*ValidationError) {
+	if count := countSelected(values); count > 0 && count < v.Min {
+		return false, []*ValidationError{NewValidationError("TOO_FEW_SELECTED", "", Params{"Min": v.Min})}
+	}
+
+	return true, []*ValidationError{}
+}
+
+// MaxSelected validator checks if at most given number of options is selected
+//     validator := &MaxSelected{3}
+type MaxSelected struct {
+	Max int
+}
+
+// IsValid checks is entered data are correct
+func (v *MaxSelected) IsValid(values []string) (bool, []*ValidationError) {
+	if countSelected(values) > v.Max {
+		return false, []*ValidationError{NewValidationError("TOO_MANY_SELECTED", "", Params{"Max": v.Max})}
+	}
+
+	return true, []*ValidationError{}
+}
+
+// FormValidator is interface for validators that check whole form, they are
+// run after all fields are successfully validated and receive cleaned data.
+// Errors should be added using form's AddError, AddFieldError or
+// AddValidationError methods.
+type FormValidator interface {
+	IsValid(form *Form, data Data) bool
+}
+
+// FormValidatorFunc allows to use ordinary function as form validator
+type FormValidatorFunc func(form *Form, data Data) bool
+
+// IsValid calls wrapped function
+func (fn FormValidatorFunc) IsValid(form *Form, data Data) bool {
+	return fn(form, data)
+}
+
+// FieldsEqual validator checks if two fields have the same value, error is
+// added to the second field
+//     validator := &FieldsEqual{"password", "password_confirm"}
+type FieldsEqual struct {
+	Field string
+	Other string
+}
+
+// IsValid checks is entered data are correct
+func (v *FieldsEqual) IsValid(form *Form, data Data) bool {
+	if reflect.DeepEqual(data[v.Field], data[v.Other]) {
+		return true
+	}
+
+	err := NewValidationError("FIELDS_NOT_EQUAL", "", Params{"Other": v.Field})
+	err.Field = v.Other
+	form.AddValidationError(err)
+	return false
+}
+
+// FileValidator is interface for validators that check uploaded files, they
+// are used with file types (File, MultipleFile). Their IsValid method is not
+// called by field.
+type FileValidator interface {
+	Validator
+	IsValidFiles(files []*multipart.FileHeader) (bool, []*ValidationError)
+}
+
+// validateFiles runs check on every file and collects errors
+func validateFiles(files []*multipart.FileHeader, fn func(*multipart.FileHeader) *ValidationError) (bool, []*ValidationError) {
+	result := true
+	errs := []*ValidationError{}
+	for _, file := range files {
+		if err := fn(file); err != nil {
+			result = false
+			errs = append(errs, err)
+		}
+	}
+
+	return result, errs
+}
+
+// MaxFileSize validator checks if uploaded files aren't bigger than given
+// number of bytes
+//     validator := &MaxFileSize{2 << 20}
+type MaxFileSize struct {
+	Max int64
+}
+
+// IsValid always passes, files are checked by IsValidFiles
+func (v *MaxFileSize) IsValid(values []string) (bool, []*ValidationError) {
+	return true, []*ValidationError{}
+}
+
+// IsValidFiles checks if uploaded files are correct
+func (v *MaxFileSize) IsValidFiles(files []*multipart.FileHeader) (bool, []*ValidationError) {
+	return validateFiles(files, func(file *multipart.FileHeader) *ValidationError {
+		if file.Size > v.Max {
+			return NewValidationError("FILE_TOO_BIG", file.Filename, Params{"Max": v.Max})
+		}
+		return nil
+	})
+}
+
+// FileExtension validator checks if uploaded files have one of given
+// extensions, comparison is case insensitive
+//     validator := &FileExtension{[]string{".jpg", ".png"}}
+type FileExtension struct {
+	Extensions []string
+}
+
+// IsValid always passes, files are checked by IsValidFiles
+func (v *FileExtension) IsValid(values []string) (bool, []*ValidationError) {
+	return true, []*ValidationError{}
+}
+
+// IsValidFiles checks if uploaded files are correct
+func (v *FileExtension) IsValidFiles(files []*multipart.FileHeader) (bool, []*ValidationError) {
+	return validateFiles(files, func(file *multipart.FileHeader) *ValidationError {
+		ext := strings.ToLower(filepath.Ext(file.Filename))
+		for _, allowed := range v.Extensions {
+			if ext != "" && ext == strings.ToLower("."+strings.TrimPrefix(allowed, ".")) {
+				return nil
+			}
+		}
+		return NewValidationError("FILE_EXTENSION", file.Filename, Params{"Extensions": strings.Join(v.Extensions, ", ")})
+	})
+}
+
+// FileMIME validator checks type of uploaded files, type is detected from
+// file's content (see http.DetectContentType), not from sent headers. Types
+// can use wildcard for subtype, ie. "image/*".
+//     validator := &FileMIME{[]string{"image/*", "application/pdf"}}
+type FileMIME struct {
+	Types []string
+}
+
+// IsValid always passes, files are checked by IsValidFiles
+func (v *FileMIME) IsValid(values []string) (bool, []*ValidationError) {
+	return true, []*ValidationError{}
+}
+
+// IsValidFiles checks if uploaded files are correct
+func (v *FileMIME) IsValidFiles(files []*multipart.FileHeader) (bool, []*ValidationError) {
+	return validateFiles(files, func(file *multipart.FileHeader) *ValidationError {
+		mimeType, err := detectFileType(file)
+		if err != nil {
+			return NewValidationError("FILE_READ_ERROR", file.Filename, nil)
+		}
+		for _, allowed := range v.Types {
+			if mimeType == allowed {
+				return nil
+			}
+			if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(allowed, "*")) {
+				return nil
+			}
+		}
+		return NewValidationError("FILE_TYPE", file.Filename, Params{"Type": mimeType})
+	})
+}
+
+// detectFileType returns MIME type of file sniffed from its first bytes,
+// without parameters like charset
+func detectFileType(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	mimeType := http.DetectContentType(buf[:n])
+	if i := strings.Index(mimeType, ";"); i >= 0 {
+		mimeType = mimeType[:i]
+	}
+
+	return mimeType, nil
+}
+
+// MaxFiles validator checks if number of uploaded files doesn't exceed given
+// value
+//     validator := &MaxFiles{5}
+type MaxFiles struct {
+	Max int
+}
+
+// IsValid always passes, files are checked by IsValidFiles
+func (v *MaxFiles) IsValid(values []string) (bool, []*ValidationError) {
+	return true, []*ValidationError{}
+}
+
+// IsValidFiles checks if uploaded files are correct
+func (v *MaxFiles) IsValidFiles(files []*multipart.FileHeader) (bool, []*ValidationError) {
+	if len(files) > v.Max {
+		return false, []*ValidationError{NewValidationError("TOO_MANY_FILES", "", Params{"Max": v.Max})}
+	}
+
+	return true, []*ValidationError{}
+}
+
+// DependentValidator is interface for validators that depend on values of
+// other fields of the form. Data contains values of active fields, under
+// their names. Their IsValid method is not called by form.
+type DependentValidator interface {
+	Validator
+	IsValidData(values []string, data url.Values) (bool, []*ValidationError)
+}
+
+// isEmpty checks if values are empty, the same way as Required does
+func isEmpty(values []string) bool {
+	return len(values) == 0 || len(values[0]) == 0
+}
+
+// requiredError returns error of field required because of other field
+func requiredError(other string) []*ValidationError {
+	return []*ValidationError{NewValidationError("REQUIRED", "", Params{"Other": other})}
+}
+
+// RequiredIf validator requires value when other field has one of given
+// values
+//     validator := &RequiredIf{Field: "account_type", Values: []string{"business"}}
+type RequiredIf struct {
+	Field  string
+	Values []string
+}
+
+// IsValid checks is entered data are correct, without other fields' values
+// field is never required
+func (v *RequiredIf) IsValid(values []string) (bool, []*ValidationError) {
+	return v.IsValidData(values, nil)
+}
+
+// IsValidData checks is entered data are correct
+func (v *RequiredIf) IsValidData(values []string, data url.Values) (bool, []*ValidationError) {
+	if isEmpty(values) && (Condition{Operator: ConditionIn, Values: v.Values}).IsMet(data[v.Field]) {
+		return false, requiredError(v.Field)
+	}
+
+	return true, []*ValidationError{}
+}
+
+// RequiredUnless validator requires value unless other field has one of given
+// values
+//     validator := &RequiredUnless{Field: "country", Values: []string{"PL"}}
+type RequiredUnless struct {
+	Field  string
+	Values []string
+}
+
+// IsValid checks is entered data are correct, without other fields' values
+// field is always required
+func (v *RequiredUnless) IsValid(values []string) (bool, []*ValidationError) {
+	return v.IsValidData(values, nil)
+}
+
+// IsValidData checks is entered data are correct
+func (v *RequiredUnless) IsValidData(values []string, data url.Values) (bool, []*ValidationError) {
+	if isEmpty(values) && !(Condition{Operator: ConditionIn, Values: v.Values}).IsMet(data[v.Field]) {
+		return false, requiredError(v.Field)
+	}
+
+	return true, []*ValidationError{}
+}
+
+// RequiredWith validator requires value when any of other fields isn't empty
+//     validator := &RequiredWith{Fields: []string{"street", "city"}}
+type RequiredWith struct {
+	Fields []string
+}
+
+// IsValid checks is entered data are correct, without other fields' values
+// field is never required
+func (v *RequiredWith) IsValid(values []string) (bool, []*ValidationError) {
+	return v.IsValidData(values, nil)
+}
+
+// IsValidData checks is entered data are correct
+func (v *RequiredWith) IsValidData(values []string, data url.Values) (bool, []*ValidationError) {
+	if !isEmpty(values) {
+		return true, []*ValidationError{}
+	}
+
+	for _, field := range v.Fields {
+		if WhenNotEmpty(field).IsMet(data[field]) {
+			return false, requiredError(field)
+		}
+	}
+
+	return true, []*ValidationError{}
 }
@@ -2,7 +2,6 @@ package forms
 
 import (
 	"fmt"
-	"html"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,7 +11,7 @@ type ValidatorResults []struct {
 	validator Validator
 	test      []string
 	result    bool
-	message   []string
+	errors    []*ValidationError
 }
 
 type ValidatorTestsSet struct {
@@ -20,24 +19,31 @@ type ValidatorTestsSet struct {
 	results ValidatorResults
 }
 
-func prepareMsg(msg string, v1, v2 interface{}) string {
-	return fmt.Sprintf(fmt.Sprintf(msg, v1), v2)
+// errorMessages returns messages of given errors
+func errorMessages(errs []*ValidationError) []string {
+	msgs := []string{}
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return msgs
 }
 
-func makeSafe(msgs []string) []string {
-	safeMsgs := []string{}
-	for _, msg := range msgs {
-		safeMsgs = append(safeMsgs, html.EscapeString(msg))
+// errorCodes returns codes of given errors
+func errorCodes(errs []*ValidationError) []string {
+	codes := []string{}
+	for _, err := range errs {
+		codes = append(codes, err.Code)
 	}
 
-	return safeMsgs
+	return codes
 }
 
 func executeValidatorTests(t *testing.T, results ValidatorTestsSet) {
 	for _, result := range results.results {
-		r, msgs := result.validator.IsValid(result.test)
+		r, errs := result.validator.IsValid(result.test)
 
-		assert.Equal(t, msgs, makeSafe(result.message), "Incorrect message for \"%s\"", result.validator)
+		assert.Equal(t, errs, result.errors, "Incorrect errors for \"%s\"", result.validator)
 		if result.result {
 			assert.True(
 				t, r, fmt.Sprintf(
@@ -56,8 +62,8 @@ func TestRequiredValidator(t *testing.T) {
 	var results = ValidatorTestsSet{
 		name: "Required",
 		results: ValidatorResults{
-			{&Required{}, []string{"asd"}, true, []string{}},
-			{&Required{}, []string{}, false, []string{translations["REQUIRED"]}},
+			{&Required{}, []string{"asd"}, true, []*ValidationError{}},
+			{&Required{}, []string{}, false, []*ValidationError{NewValidationError("REQUIRED", "", nil)}},
 		},
 	}
 
@@ -68,13 +74,13 @@ func TestRegexpValidator(t *testing.T) {
 	var results = ValidatorTestsSet{
 		name: "Regexp",
 		results: ValidatorResults{
-			{&Regexp{}, []string{"asd"}, false, []string{prepareMsg(translations["NO_MATCH_PATTERN"], "", "asd")}},
-			{&Regexp{""}, []string{"asd"}, false, []string{prepareMsg(translations["NO_MATCH_PATTERN"], "", "asd")}},
-			{&Regexp{""}, []string{""}, true, []string{}},
-			{&Regexp{"^[a-d]*$"}, []string{"accdddaabbcc"}, true, []string{}},
-			{&Regexp{"^[a-d]*$"}, []string{"accdddaabbcce"}, false, []string{prepareMsg(translations["NO_MATCH_PATTERN"], "^[a-d]*$", "accdddaabbcce")}},
-			{&Regexp{"[0-9]*"}, []string{"accddd123aabbcce"}, true, []string{}},
-			{&Regexp{"^[0-9]*$"}, []string{"accddd123aabbcce"}, false, []string{prepareMsg(translations["NO_MATCH_PATTERN"], "^[0-9]*$", "accddd123aabbcce")}},
+			{&Regexp{}, []string{"asd"}, false, []*ValidationError{NewValidationError("NO_MATCH_PATTERN", "asd", Params{"Pattern": ""})}},
+			{&Regexp{""}, []string{"asd"}, false, []*ValidationError{NewValidationError("NO_MATCH_PATTERN", "asd", Params{"Pattern": ""})}},
+			{&Regexp{""}, []string{""}, true, []*ValidationError{}},
+			{&Regexp{"^[a-d]*$"}, []string{"accdddaabbcc"}, true, []*ValidationError{}},
+			{&Regexp{"^[a-d]*$"}, []string{"accdddaabbcce"}, false, []*ValidationError{NewValidationError("NO_MATCH_PATTERN", "accdddaabbcce", Params{"Pattern": "^[a-d]*$"})}},
+			{&Regexp{"[0-9]*"}, []string{"accddd123aabbcce"}, true, []*ValidationError{}},
+			{&Regexp{"^[0-9]*$"}, []string{"accddd123aabbcce"}, false, []*ValidationError{NewValidationError("NO_MATCH_PATTERN", "accddd123aabbcce", Params{"Pattern": "^[0-9]*$"})}},
 		},
 	}
 
@@ -85,16 +91,16 @@ func TestEmailValidator(t *testing.T) {
 	var results = ValidatorTestsSet{
 		name: "Email",
 		results: ValidatorResults{
-			{&Email{}, []string{}, true, []string{}},
-			{&Email{}, []string{"foo"}, false, []string{fmt.Sprintf(translations["INCORRECT_EMAIL"], "foo")}},
-			{&Email{}, []string{"foo@ham"}, false, []string{fmt.Sprintf(translations["INCORRECT_EMAIL"], "foo@ham")}},
-			{&Email{}, []string{"foo@ham.p"}, false, []string{fmt.Sprintf(translations["INCORRECT_EMAIL"], "foo@ham.p")}},
-			{&Email{}, []string{"foo@ham.pl"}, true, []string{}},
-			{&Email{}, []string{"foo+bar@ham"}, false, []string{fmt.Sprintf(translations["INCORRECT_EMAIL"], "foo+bar@ham")}},
-			{&Email{}, []string{"foo+bar@ham.pl"}, true, []string{}},
-
-			{&Email{}, []string{"foo@h_am.pl"}, false, []string{fmt.Sprintf(translations["INCORRECT_EMAIL"], "foo@h_am.pl")}},
-			{&Email{}, []string{"foo+bar@h_am.pl"}, false, []string{fmt.Sprintf(translations["INCORRECT_EMAIL"], "foo+bar@h_am.pl")}},
+			{&Email{}, []string{}, true, []*ValidationError{}},
+			{&Email{}, []string{"foo"}, false, []*ValidationError{NewValidationError("INCORRECT_EMAIL", "foo", nil)}},
+			{&Email{}, []string{"foo@ham"}, false, []*ValidationError{NewValidationError("INCORRECT_EMAIL", "foo@ham", nil)}},
+			{&Email{}, []string{"foo@ham.p"}, false, []*ValidationError{NewValidationError("INCORRECT_EMAIL", "foo@ham.p", nil)}},
+			{&Email{}, []string{"foo@ham.pl"}, true, []*ValidationError{}},
+			{&Email{}, []string{"foo+bar@ham"}, false, []*ValidationError{NewValidationError("INCORRECT_EMAIL", "foo+bar@ham", nil)}},
+			{&Email{}, []string{"foo+bar@ham.pl"}, true, []*ValidationError{}},
+
+			{&Email{}, []string{"foo@h_am.pl"}, false, []*ValidationError{NewValidationError("INCORRECT_EMAIL", "foo@h_am.pl", nil)}},
+			{&Email{}, []string{"foo+bar@h_am.pl"}, false, []*ValidationError{NewValidationError("INCORRECT_EMAIL", "foo+bar@h_am.pl", nil)}},
 		},
 	}
 
@@ -105,12 +111,12 @@ func TestMinLengthValidator(t *testing.T) {
 	var results = ValidatorTestsSet{
 		name: "MinLength",
 		results: ValidatorResults{
-			{&MinLength{Min: 2}, []string{}, true, []string{}},
-			{&MinLength{Min: 2}, []string{""}, true, []string{}},
-			{&MinLength{Min: 2}, []string{"foo"}, true, []string{}},
-			{&MinLength{Min: 2}, []string{"foo", "a"}, false, []string{prepareMsg(translations["INCORRECT_MIN_LENGTH"], 2, "a")}},
-			{&MinLength{Min: 3}, []string{"foo"}, true, []string{}},
-			{&MinLength{Min: 4}, []string{"foo"}, false, []string{prepareMsg(translations["INCORRECT_MIN_LENGTH"], 4, "foo")}},
+			{&MinLength{Min: 2}, []string{}, true, []*ValidationError{}},
+			{&MinLength{Min: 2}, []string{""}, true, []*ValidationError{}},
+			{&MinLength{Min: 2}, []string{"foo"}, true, []*ValidationError{}},
+			{&MinLength{Min: 2}, []string{"foo", "a"}, false, []*ValidationError{NewValidationError("INCORRECT_MIN_LENGTH", "a", Params{"Min": 2})}},
+			{&MinLength{Min: 3}, []string{"foo"}, true, []*ValidationError{}},
+			{&MinLength{Min: 4}, []string{"foo"}, false, []*ValidationError{NewValidationError("INCORRECT_MIN_LENGTH", "foo", Params{"Min": 4})}},
 		},
 	}
 
@@ -121,12 +127,12 @@ func TestMaxLengthValidator(t *testing.T) {
 	var results = ValidatorTestsSet{
 		name: "MaxLength",
 		results: ValidatorResults{
-			{&MaxLength{Max: 2}, []string{}, true, []string{}},
-			{&MaxLength{Max: 2}, []string{""}, true, []string{}},
-			{&MaxLength{Max: 5}, []string{"foo", "asdasdd"}, false, []string{prepareMsg(translations["INCORRECT_MAX_LENGTH"], 5, "asdasdd")}},
-			{&MaxLength{Max: 2}, []string{"foo"}, false, []string{prepareMsg(translations["INCORRECT_MAX_LENGTH"], 2, "foo")}},
-			{&MaxLength{Max: 3}, []string{"foo"}, true, []string{}},
-			{&MaxLength{Max: 4}, []string{"foo"}, true, []string{}},
+			{&MaxLength{Max: 2}, []string{}, true, []*ValidationError{}},
+			{&MaxLength{Max: 2}, []string{""}, true, []*ValidationError{}},
+			{&MaxLength{Max: 5}, []string{"foo", "asdasdd"}, false, []*ValidationError{NewValidationError("INCORRECT_MAX_LENGTH", "asdasdd", Params{"Max": 5})}},
+			{&MaxLength{Max: 2}, []string{"foo"}, false, []*ValidationError{NewValidationError("INCORRECT_MAX_LENGTH", "foo", Params{"Max": 2})}},
+			{&MaxLength{Max: 3}, []string{"foo"}, true, []*ValidationError{}},
+			{&MaxLength{Max: 4}, []string{"foo"}, true, []*ValidationError{}},
 		},
 	}
 
@@ -139,15 +145,63 @@ func TestInSliceValidator(t *testing.T) {
 		name: "InSlice",
 		results: ValidatorResults{
 			{&InSlice{Values: []string{""}}, []string{"foo"}, false,
-				[]string{fmt.Sprintf(translations["VALUE_NOT_FOUND"], "foo")}},
-			{&InSlice{Values: []string{""}}, []string{""}, true, []string{}},
-			{&InSlice{Values: []string{}}, []string{""}, true, []string{}},
-			{&InSlice{Values: testSlice}, []string{"spam"}, true, []string{}},
+				[]*ValidationError{NewValidationError("VALUE_NOT_FOUND", "foo", nil)}},
+			{&InSlice{Values: []string{""}}, []string{""}, true, []*ValidationError{}},
+			{&InSlice{Values: []string{}}, []string{""}, true, []*ValidationError{}},
+			{&InSlice{Values: testSlice}, []string{"spam"}, true, []*ValidationError{}},
 			{&InSlice{Values: testSlice}, []string{"spa", "asd"}, false,
-				[]string{fmt.Sprintf(translations["VALUE_NOT_FOUND"], "spa"),
-					fmt.Sprintf(translations["VALUE_NOT_FOUND"], "asd")}},
+				[]*ValidationError{NewValidationError("VALUE_NOT_FOUND", "spa", nil),
+					NewValidationError("VALUE_NOT_FOUND", "asd", nil)}},
 		},
 	}
 
 	executeValidatorTests(t, results)
 }
+
+func TestMinSelectedValidator(t *testing.T) {
+	var results = ValidatorTestsSet{
+		name: "MinSelected",
+		results: ValidatorResults{
+			{&MinSelected{Min: 2}, []string{}, true, []*ValidationError{}},
+			{&MinSelected{Min: 2}, []string{""}, true, []*ValidationError{}},
+			{&MinSelected{Min: 2}, []string{"a", ""}, false, []*ValidationError{NewValidationError("TOO_FEW_SELECTED", "", Params{"Min": 2})}},
+			{&MinSelected{Min: 2}, []string{"a", "b"}, true, []*ValidationError{}},
+			{&MinSelected{Min: 2}, []string{"a", "b", "c"}, true, []*ValidationError{}},
+		},
+	}
+
+	executeValidatorTests(t, results)
+}
+
+func TestMaxSelectedValidator(t *testing.T) {
+	var results = ValidatorTestsSet{
+		name: "MaxSelected",
+		results: ValidatorResults{
+			{&MaxSelected{Max: 2}, []string{}, true, []*ValidationError{}},
+			{&MaxSelected{Max: 2}, []string{"a", "b", ""}, true, []*ValidationError{}},
+			{&MaxSelected{Max: 2}, []string{"a", "b", "c"}, false, []*ValidationError{NewValidationError("TOO_MANY_SELECTED", "", Params{"Max": 2})}},
+			{&MaxSelected{Max: 0}, []string{"a"}, false, []*ValidationError{NewValidationError("TOO_MANY_SELECTED", "", Params{"Max": 0})}},
+		},
+	}
+
+	executeValidatorTests(t, results)
+}
+
+func TestFieldsEqualValidator(t *testing.T) {
+	f := New(
+		map[string]*Field{
+			"password":         &Field{},
+			"password_confirm": &Field{},
+		},
+		nil,
+	)
+	f.Validators = []FormValidator{&FieldsEqual{"password", "password_confirm"}}
+
+	assert.True(t, f.IsValidMap(map[string]interface{}{"password": "foo", "password_confirm": "foo"}))
+	assert.False(t, f.IsValidMap(map[string]interface{}{"password": "foo", "password_confirm": "bar"}))
+	assert.Equal(
+		t, f.Fields["password_confirm"].Errors,
+		[]*ValidationError{{Code: "FIELDS_NOT_EQUAL", Field: "password_confirm", Params: Params{"Other": "password"}}},
+	)
+	assert.False(t, f.Fields["password"].HasErrors())
+}